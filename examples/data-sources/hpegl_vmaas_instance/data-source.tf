@@ -0,0 +1,12 @@
+# (C) Copyright 2022 Hewlett Packard Enterprise Development LP
+
+data "hpegl_vmaas_instance" "web" {
+  name = "web_instance"
+}
+
+data "hpegl_vmaas_instance" "db" {
+  labels = ["db"]
+  tags = {
+    env = "prod"
+  }
+}
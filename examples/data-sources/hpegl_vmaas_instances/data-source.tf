@@ -0,0 +1,9 @@
+# (C) Copyright 2022 Hewlett Packard Enterprise Development LP
+
+data "hpegl_vmaas_instances" "prod" {
+  group_id = data.hpegl_vmaas_group.default_group.id
+  status   = "running"
+  tags = {
+    env = "prod"
+  }
+}
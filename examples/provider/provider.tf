@@ -14,6 +14,8 @@ provider "hpegl" {
   vmaas {
     location   = "location"
     space_name = "space_name"
+    # optional, interval in seconds between status polls
+    # poll_interval = 30
   }
   iam_service_url = "https://iam.us1.greenlake-hpe.com"
   tenant_id       = "<GLC-Tenant-ID>"
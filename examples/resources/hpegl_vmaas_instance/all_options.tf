@@ -25,6 +25,7 @@ resource "hpegl_vmaas_instance" "tf_instance" {
     name         = "local_vol"
     size         = 5
     datastore_id = data.hpegl_vmaas_datastore.c_3par.id
+    storage_type = 2
   }
 
   labels = ["test_label"]
@@ -35,20 +36,36 @@ resource "hpegl_vmaas_instance" "tf_instance" {
   }
 
   config {
-    resource_pool_id = data.hpegl_vmaas_resource_pool.cl_resource_pool.id
-    template_id      = data.hpegl_vmaas_template.vanilla.id
-    no_agent         = true
-    asset_tag        = "vm_tag"
-    folder_code      = data.hpegl_vmaas_cloud_folder.compute_folder.code
-    create_user      = true
+    resource_pool_id   = data.hpegl_vmaas_resource_pool.cl_resource_pool.id
+    template_id        = data.hpegl_vmaas_template.vanilla.id
+    no_agent           = true
+    asset_tag          = "vm_tag"
+    folder_code        = data.hpegl_vmaas_cloud_folder.compute_folder.code
+    create_user        = true
+    vmware_custom_spec = "win_sysprep_spec"
+    vmware_domain_name = "example.local"
   }
   hostname = "tf_host_1"
   scale    = 2
   evars = {
     proxy = "http://address:port"
   }
+  evar {
+    name   = "db_password"
+    value  = var.db_password
+    masked = true
+  }
   env_prefix        = "tf_test"
   power_schedule_id = data.hpegl_vmaas_power_schedule.weekday.id
+  expire_days       = 30
+  shutdown_days     = 14
+  wait_for {
+    ip_assigned             = true
+    all_nics_addressed      = true
+    agent_connected         = true
+    agent_connected_timeout = "30m"
+    timeout                 = "15m"
+  }
   port {
     name = "nginx"
     port = 80
@@ -68,3 +85,8 @@ resource "hpegl_vmaas_instance" "tf_instance" {
     description = "test snapshot description is optional"
   }
 }
+
+variable "db_password" {
+  type      = string
+  sensitive = true
+}
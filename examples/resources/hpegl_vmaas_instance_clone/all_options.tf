@@ -40,6 +40,11 @@ resource "hpegl_vmaas_instance_clone" "tf_instance_clone" {
   evars = {
     proxy = "http://address:port"
   }
+  evar {
+    name   = "db_password"
+    value  = var.db_password
+    masked = true
+  }
   power_schedule_id = data.hpegl_vmaas_powerSchedule.weekday.id
   # any update in snapshot will end up to creating new snapshot and existing
   # snapshot will be still in backend.
@@ -48,3 +53,8 @@ resource "hpegl_vmaas_instance_clone" "tf_instance_clone" {
     description = "test snapshot description is optional"
   }
 }
+
+variable "db_password" {
+  type      = string
+  sensitive = true
+}
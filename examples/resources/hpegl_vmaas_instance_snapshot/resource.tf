@@ -0,0 +1,8 @@
+# (C) Copyright 2022 Hewlett Packard Enterprise Development LP
+
+# create snapshot of an instance before patching
+resource "hpegl_vmaas_instance_snapshot" "tf_snapshot" {
+  instance_id = hpegl_vmaas_instance.tf_instance.id
+  name        = "pre_patch_snapshot"
+  description = "snapshot before patch window"
+}
@@ -0,0 +1,9 @@
+# (C) Copyright 2022 Hewlett Packard Enterprise Development LP
+
+# add a data volume to an instance
+resource "hpegl_vmaas_instance_volume" "tf_data_volume" {
+  instance_id  = hpegl_vmaas_instance.tf_instance.id
+  name         = "data_volume"
+  size         = 50
+  datastore_id = data.hpegl_vmaas_datastore.c_3par.id
+}
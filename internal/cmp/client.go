@@ -7,8 +7,10 @@ import apiClient "github.com/HewlettPackard/hpegl-vmaas-cmp-go-sdk/pkg/client"
 // Client is the cmp client which will implements all the
 // functions in interface.go
 type Client struct {
-	Instance                  Resource
-	InstanceClone             Resource
+	Instance                  ResourceImport
+	InstanceClone             ResourceImportDiff
+	InstanceSnapshot          Resource
+	InstanceVolume            Resource
 	Router                    Resource
 	ResNetwork                Resource
 	RouterNat                 Resource
@@ -40,6 +42,8 @@ type Client struct {
 	EdgeCluster               DataSource
 	TransportZone             DataSource
 	DSLoadBalancer            DataSource
+	DSInstance                DataSource
+	DSInstances               DataSource
 }
 
 // NewClient returns configured client
@@ -53,6 +57,14 @@ func NewClient(client *apiClient.APIClient, cfg apiClient.Configuration) *Client
 		InstanceClone: newInstanceClone(
 			&apiClient.InstancesAPIService{Client: client, Cfg: cfg},
 			&apiClient.ServersAPIService{Client: client, Cfg: cfg},
+			&apiClient.CloudsAPIService{Client: client, Cfg: cfg},
+			&apiClient.LibraryAPIService{Client: client, Cfg: cfg},
+		),
+		InstanceSnapshot: newInstanceSnapshot(
+			&apiClient.InstancesAPIService{Client: client, Cfg: cfg},
+		),
+		InstanceVolume: newInstanceVolume(
+			&apiClient.InstancesAPIService{Client: client, Cfg: cfg},
 		),
 		ResNetwork: newResNetwork(
 			&apiClient.NetworksAPIService{Client: client, Cfg: cfg},
@@ -91,5 +103,9 @@ func NewClient(client *apiClient.APIClient, cfg apiClient.Configuration) *Client
 		NetworkProxy:   newNetworkProxy(&apiClient.NetworksAPIService{Client: client, Cfg: cfg}),
 		TransportZone:  newTransportZone(&apiClient.RouterAPIService{Client: client, Cfg: cfg}),
 		EdgeCluster:    newEdgeCluster(&apiClient.RouterAPIService{Client: client, Cfg: cfg}),
+		DSInstance: newInstanceDS(&apiClient.InstancesAPIService{Client: client, Cfg: cfg},
+			&apiClient.ServersAPIService{Client: client, Cfg: cfg}),
+		DSInstances: newInstancesDS(&apiClient.InstancesAPIService{Client: client, Cfg: cfg},
+			&apiClient.ServersAPIService{Client: client, Cfg: cfg}),
 	}
 }
@@ -2,7 +2,10 @@
 
 package cmp
 
-import "time"
+import (
+	"errors"
+	"time"
+)
 
 const (
 	vmware        = "vmware"
@@ -10,18 +13,29 @@ const (
 	nsxt          = "NSX-T"
 	errExactMatch = "error, could not find the %s with the specified name. Please verify the name and try again"
 	successErr    = "got success = 'false while %s"
+	// errDeletionProtection is returned on deleting a resource with deletion_protection enabled
+	errDeletionProtection = "error, %s %s can not be deleted since deletion_protection is enabled. " +
+		"Set deletion_protection to false and apply before deleting"
 	// query params keys
 	provisionTypeKey = "provisionType"
 	codeKey          = "code"
 	nameKey          = "name"
 	maxKey           = "max"
-	externalNameKey  = "externalName"
 	filterTypeKey    = "filterType"
 	// retry related constants
-	maxTimeout = time.Hour * 2
+	routerRetryDelay       = time.Second * 5
+	loadBalancerRetryDelay = time.Second * 30
+	// mutating calls are retried a bounded number of times
+	loadBalancerUpdateRetryCount = 3
 	// router consts
 	tier0GatewayType             = "NSX-T Tier-0 Gateway"
 	tier1GatewayType             = "NSX-T Tier-1 Gateway"
 	routerFirewallExternalPolicy = "GatewayPolicy"
 	syncedTypeValue              = "Synced"
 )
+
+var (
+	errInstanceFailed          = errors.New("error, instance provisioning failed")
+	errInstancePendingApproval = errors.New("error, instance provisioning is pending approval")
+	errInstanceApprovalDenied  = errors.New("error, instance provisioning approval is denied")
+)
@@ -3,9 +3,12 @@
 package cmp
 
 import (
+	"fmt"
 	"log"
+	"sync"
 
 	"github.com/HewlettPackard/hpegl-vmaas-cmp-go-sdk/pkg/client"
+	"github.com/HewlettPackard/hpegl-vmaas-terraform-resources/internal/utils"
 	"github.com/HewlettPackard/hpegl-vmaas-terraform-resources/pkg/auth"
 )
 
@@ -15,3 +18,38 @@ func setMeta(meta interface{}, apiClient client.APIClientHandler) {
 		log.Printf("[ERROR] error while setting meta information for cmp-sdk, error: %v", err)
 	}
 }
+
+// checkDeletionProtection returns error if deletion_protection is enabled for the resource
+func checkDeletionProtection(d *utils.Data, resource string) error {
+	if d.GetBool("deletion_protection") {
+		return fmt.Errorf(errDeletionProtection, resource, d.Id())
+	}
+
+	return nil
+}
+
+// instanceLocks holds a mutex per instance ID, to serialize the operations on the same instance
+type instanceLocks struct {
+	sync.Mutex
+	locks map[int]*sync.Mutex
+}
+
+func newInstanceLocks() *instanceLocks {
+	return &instanceLocks{
+		locks: make(map[int]*sync.Mutex),
+	}
+}
+
+// lock locks the operations of the instance and returns the unlock function
+func (l *instanceLocks) lock(instanceID int) func() {
+	l.Lock()
+	m, ok := l.locks[instanceID]
+	if !ok {
+		m = &sync.Mutex{}
+		l.locks[instanceID] = m
+	}
+	l.Unlock()
+	m.Lock()
+
+	return m.Unlock
+}
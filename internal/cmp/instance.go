@@ -10,6 +10,7 @@ import (
 	"github.com/HewlettPackard/hpegl-vmaas-cmp-go-sdk/pkg/client"
 	"github.com/HewlettPackard/hpegl-vmaas-cmp-go-sdk/pkg/models"
 	"github.com/HewlettPackard/hpegl-vmaas-terraform-resources/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 // instance implements functions related to cmp instances
@@ -54,7 +55,7 @@ func (i *instance) Create(ctx context.Context, d *utils.Data, meta interface{})
 		},
 		Environment:       d.GetString("environment_code"),
 		Ports:             instanceGetPorts(d.GetListMap("port")),
-		Evars:             instanceGetEvars(d.GetMap("evars")),
+		Evars:             instanceGetEvars(d.GetMap("evars"), d.GetListMap("evar")),
 		Labels:            d.GetStringList("labels"),
 		Volumes:           instanceGetVolume(d.GetListMap("volume")),
 		NetworkInterfaces: instanceGetNetwork(d.GetListMap("network")),
@@ -63,6 +64,13 @@ func (i *instance) Create(ctx context.Context, d *utils.Data, meta interface{})
 		LayoutSize:        d.GetInt("scale"),
 		PowerScheduleType: d.GetJSONNumber("power_schedule_id"),
 	}
+	// zero expire or shutdown days are not sent, since instance would be expired or shut down immediately
+	if expireDays := d.GetInt("expire_days"); expireDays > 0 {
+		req.ExpireDays = utils.JSONNumber(expireDays)
+	}
+	if shutdownDays := d.GetInt("shutdown_days"); shutdownDays > 0 {
+		req.ShutdownDays = utils.JSONNumber(shutdownDays)
+	}
 
 	// Pre check
 	if err := d.Error(); err != nil {
@@ -75,8 +83,15 @@ func (i *instance) Create(ctx context.Context, d *utils.Data, meta interface{})
 		return err
 	}
 	getInstanceBody := *respVM.Instance
+	// set ID right after creation, so that the instance is kept in the state (as tainted)
+	// even if any of the following steps fail
+	d.SetID(getInstanceBody.ID)
 
-	if err := instanceWaitUntilCreated(ctx, i.instanceSharedClient, meta, getInstanceBody.ID); err != nil {
+	if err := instanceWaitUntilCreated(ctx, i.instanceSharedClient, meta, getInstanceBody.ID,
+		d.Timeout(schema.TimeoutCreate), instanceGetApprovalTimeout(d)); err != nil {
+		return instanceHandleCreateFailure(ctx, i.instanceSharedClient, d, meta, err)
+	}
+	if err := instanceWaitForReadiness(ctx, i.instanceSharedClient, d, meta, getInstanceBody.ID); err != nil {
 		return err
 	}
 
@@ -97,7 +112,6 @@ func (i *instance) Create(ctx context.Context, d *utils.Data, meta interface{})
 		return err
 	}
 
-	d.SetID(getInstanceBody.ID)
 	// post check
 	return d.Error()
 }
@@ -109,6 +123,9 @@ func (i *instance) Update(ctx context.Context, d *utils.Data, meta interface{})
 }
 
 func (i *instance) Delete(ctx context.Context, d *utils.Data, meta interface{}) error {
+	if err := checkDeletionProtection(d, "instance"); err != nil {
+		return err
+	}
 	setMeta(meta, i.iClient.Client)
 
 	return deleteInstance(ctx, i.instanceSharedClient, d, meta)
@@ -119,3 +136,10 @@ func (i *instance) Read(ctx context.Context, d *utils.Data, meta interface{}) er
 
 	return readInstance(ctx, i.instanceSharedClient, d, meta, false)
 }
+
+// Import instance and populate the complete state from the API
+func (i *instance) Import(ctx context.Context, d *utils.Data, meta interface{}) error {
+	setMeta(meta, i.iClient.Client)
+
+	return importInstance(ctx, i.instanceSharedClient, d)
+}
@@ -4,34 +4,49 @@ package cmp
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
 	"fmt"
 	"log"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/HewlettPackard/hpegl-vmaas-cmp-go-sdk/pkg/client"
 	"github.com/HewlettPackard/hpegl-vmaas-cmp-go-sdk/pkg/models"
 	"github.com/HewlettPackard/hpegl-vmaas-terraform-resources/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 const (
-	instanceCloneRetryDelay = time.Second * 15
+	instanceCloneRetryDelay  = time.Second * 15
+	instanceCloneProcessCode = "cloning"
+	autoDatastore            = "auto"
 )
 
+// instanceCloneLocks serializes the clones of the same source instance, see instanceCloneSubmit
+var instanceCloneLocks = newInstanceLocks()
+
 // instanceClone implements functions related to cmp instanceClones
 type instanceClone struct {
 	// expose Instance API service to instanceClones related operations
 	instanceSharedClient
+	// clouds and library API services are used to validate the target cloud of the clone
+	cClient *client.CloudsAPIService
+	lClient *client.LibraryAPIService
 }
 
-func newInstanceClone(iClient *client.InstancesAPIService, sClient *client.ServersAPIService) *instanceClone {
+func newInstanceClone(
+	iClient *client.InstancesAPIService,
+	sClient *client.ServersAPIService,
+	cClient *client.CloudsAPIService,
+	lClient *client.LibraryAPIService,
+) *instanceClone {
 	return &instanceClone{
 		instanceSharedClient: instanceSharedClient{
 			iClient: iClient,
 			sClient: sClient,
 		},
+		cClient: cClient,
+		lClient: lClient,
 	}
 }
 
@@ -56,7 +71,7 @@ func (i *instanceClone) Create(ctx context.Context, d *utils.Data, meta interfac
 		Plan:              models.IDModel{ID: d.GetInt("plan_id")},
 		LayoutSize:        d.GetInt("scale"),
 		NetworkInterfaces: instanceGetNetwork(d.GetListMap("network")),
-		Evars:             instanceGetEvars(d.GetMap("evars")),
+		Evars:             instanceGetEvars(d.GetMap("evars"), d.GetListMap("evar")),
 		Metadata:          instanceGetTags(d.GetMap("tags")),
 	}
 
@@ -76,53 +91,24 @@ func (i *instanceClone) Create(ctx context.Context, d *utils.Data, meta interfac
 		return err
 	}
 
-	// clone the instance
-	log.Printf("[INFO] Cloning the instance with %d", sourceID)
-	err = cloneInstance(ctx, i, meta, req, sourceID)
-	if err != nil {
-		return err
-	}
-
-	log.Printf("[INFO] Check history")
-	err = checkInstanceCloneHistory(ctx, i, meta, sourceID)
-	if err != nil {
-		return err
-	}
-
-	log.Printf("[INFO] Get all instances")
-	getInstanceRetry := &utils.CustomRetry{
-		RetryDelay: instanceCloneRetryDelay,
-		Timeout:    time.Minute * 2,
-		Cond: func(resp interface{}, err error) (bool, error) {
-			if err != nil {
-				return false, nil
-			}
-			instancesList := resp.(models.Instances)
-
-			return len(instancesList.Instances) == 1, nil
-		},
-	}
-	// get cloned instance ID
-	instancesResp, err := getInstanceRetry.Retry(ctx, meta, func(ctx context.Context) (interface{}, error) {
-		return i.iClient.GetAllInstances(ctx, map[string]string{
-			nameKey: req.Name,
-		})
-	})
+	cloneID, err := instanceCloneSubmit(ctx, i, d, meta, req, sourceID)
 	if err != nil {
 		return err
 	}
+	// set ID once the cloned instance is found, so that the instance is kept in the state
+	// (as tainted) even if any of the following steps fail
+	d.SetID(cloneID)
 
-	instancesList := instancesResp.(models.Instances)
-	if len(instancesList.Instances) != 1 {
-		return errors.New("get cloned instance is failed")
+	if err := instanceWaitUntilCreated(ctx, i.instanceSharedClient, meta, cloneID,
+		d.Timeout(schema.TimeoutCreate), instanceGetApprovalTimeout(d)); err != nil {
+		return instanceHandleCreateFailure(ctx, i.instanceSharedClient, d, meta, err)
 	}
-
-	if err := instanceWaitUntilCreated(ctx, i.instanceSharedClient, meta, instancesList.Instances[0].ID); err != nil {
+	if err := instanceWaitForReadiness(ctx, i.instanceSharedClient, d, meta, cloneID); err != nil {
 		return err
 	}
 
 	if snapshot := d.GetListMap("snapshot"); len(snapshot) == 1 {
-		err := createInstanceSnapshot(ctx, i.instanceSharedClient, instancesList.Instances[0].ID, models.SnapshotBody{
+		err := createInstanceSnapshot(ctx, i.instanceSharedClient, cloneID, models.SnapshotBody{
 			Snapshot: &models.SnapshotBodySnapshot{
 				Name:        snapshot[0]["name"].(string),
 				Description: snapshot[0]["description"].(string),
@@ -136,7 +122,6 @@ func (i *instanceClone) Create(ctx context.Context, d *utils.Data, meta interfac
 	if err != nil {
 		return err
 	}
-	d.SetID(instancesList.Instances[0].ID)
 
 	// post check
 	return d.Error()
@@ -153,6 +138,9 @@ func (i *instanceClone) Update(ctx context.Context, d *utils.Data, meta interfac
 
 // Delete instance and set ID as ""
 func (i *instanceClone) Delete(ctx context.Context, d *utils.Data, meta interface{}) error {
+	if err := checkDeletionProtection(d, "instance"); err != nil {
+		return err
+	}
 	setMeta(meta, i.iClient.Client)
 
 	return deleteInstance(ctx, i.instanceSharedClient, d, meta)
@@ -165,12 +153,186 @@ func (i *instanceClone) Read(ctx context.Context, d *utils.Data, meta interface{
 	return readInstance(ctx, i.instanceSharedClient, d, meta, true)
 }
 
-func checkInstanceCloneHistory(ctx context.Context, i *instanceClone, meta interface{}, instanceID int) error {
+// Import instance clone and populate the complete state from the API. Source
+// instance of a clone can not be retrieved from the API, so import ID should
+// be in the format <instance_id>:<source_instance_id>
+func (i *instanceClone) Import(ctx context.Context, d *utils.Data, meta interface{}) error {
+	setMeta(meta, i.iClient.Client)
+
+	ids := strings.Split(d.Id(), ":")
+	if len(ids) != 2 {
+		return fmt.Errorf("invalid import ID %q, expected format is <instance_id>:<source_instance_id>", d.Id())
+	}
+	sourceID, err := strconv.Atoi(ids[1])
+	if err != nil {
+		return fmt.Errorf("invalid source instance ID %q, error: %w", ids[1], err)
+	}
+	d.SetID(ids[0])
+	if err := d.Set("source_instance_id", sourceID); err != nil {
+		return err
+	}
+
+	return importInstance(ctx, i.instanceSharedClient, d)
+}
+
+// DiffValidate validates the volumes against the volumes of the source instance while planning
+// a new clone, so that a shrunk or interchanged root volume fails at plan time
+func (i *instanceClone) DiffValidate(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() != "" || !diff.NewValueKnown("source_instance_id") || !diff.NewValueKnown("volume") {
+		return nil
+	}
+	setMeta(meta, i.iClient.Client)
+
+	sourceInstance, err := i.iClient.GetASpecificInstance(ctx, diff.Get("source_instance_id").(int))
+	if err != nil {
+		return err
+	}
+
+	return instanceCloneValidateVolumes(utils.GetlistMap(diff.Get("volume")), sourceInstance.Instance.Volumes)
+}
+
+// instanceCloneSubmit clones the source instance and returns the ID of the clone. Clone API does
+// not return the clone, so the clone is identified as the new instance with the given name and its
+// process as the new cloning process of the source instance. Clones of the same source instance
+// are serialized until the clone is found, so that parallel clones can not be mixed up.
+func instanceCloneSubmit(
+	ctx context.Context,
+	i *instanceClone,
+	d *utils.Data,
+	meta interface{},
+	req models.CreateInstanceCloneBody,
+	sourceID int,
+) (int, error) {
+	unlock := instanceCloneLocks.lock(sourceID)
+	defer unlock()
+
+	// Instances with the same name and the latest cloning process of the source instance
+	// are retrieved before cloning, so that the new clone and its process can be identified
+	existingIDs, err := instanceCloneGetIDs(ctx, i, req.Name)
+	if err != nil {
+		return 0, err
+	}
+	lastProcessID, err := instanceCloneLastProcessID(ctx, i, sourceID)
+	if err != nil {
+		return 0, err
+	}
+
+	// clone the instance
+	log.Printf("[INFO] Cloning the instance with %d", sourceID)
+	err = cloneInstance(ctx, i, meta, req, sourceID)
+	if err != nil {
+		return 0, err
+	}
+
+	log.Printf("[INFO] Check history")
+	err = checkInstanceCloneHistory(ctx, i, meta, sourceID, lastProcessID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return 0, err
+	}
+
+	log.Printf("[INFO] Get all instances")
+	getInstanceRetry := &utils.CustomRetry{
+		RetryDelay: utils.GetPollInterval(meta, instanceCloneRetryDelay),
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Cond: func(resp interface{}, err error) (bool, error) {
+			if err != nil {
+				return false, nil
+			}
+			instancesList := resp.(models.Instances)
+
+			return len(instanceCloneNewIDs(instancesList.Instances, existingIDs)) > 0, nil
+		},
+	}
+	// get cloned instance ID
+	instancesResp, err := getInstanceRetry.Retry(ctx, meta, func(ctx context.Context) (interface{}, error) {
+		return i.iClient.GetAllInstances(ctx, map[string]string{
+			nameKey: req.Name,
+			maxKey:  instanceDSMaxResults,
+		})
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	newIDs := instanceCloneNewIDs(instancesResp.(models.Instances).Instances, existingIDs)
+	if len(newIDs) != 1 {
+		return 0, fmt.Errorf("error, cloned instance can not be identified, found %d new instances with the name %s",
+			len(newIDs), req.Name)
+	}
+
+	return newIDs[0], nil
+}
+
+// instanceCloneGetIDs returns the IDs of the existing instances with the given name
+func instanceCloneGetIDs(ctx context.Context, i *instanceClone, name string) (map[int]bool, error) {
+	instances, err := i.iClient.GetAllInstances(ctx, map[string]string{
+		nameKey: name,
+		maxKey:  instanceDSMaxResults,
+	})
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[int]bool, len(instances.Instances))
+	for _, instance := range instances.Instances {
+		ids[instance.ID] = true
+	}
+
+	return ids, nil
+}
+
+// instanceCloneNewIDs returns the IDs of the instances which are not in existingIDs
+func instanceCloneNewIDs(instances []models.GetInstanceResponseInstance, existingIDs map[int]bool) []int {
+	ids := make([]int, 0, 1)
+	for _, instance := range instances {
+		if !existingIDs[instance.ID] {
+			ids = append(ids, instance.ID)
+		}
+	}
+
+	return ids
+}
+
+// instanceCloneLastProcessID returns the ID of the latest cloning process of the instance,
+// returns 0 if the instance is not cloned before
+func instanceCloneLastProcessID(ctx context.Context, i *instanceClone, instanceID int) (int, error) {
+	history, err := i.iClient.GetInstanceHistory(ctx, instanceID)
+	if err != nil {
+		return 0, err
+	}
+
+	return instanceCloneLastProcess(history.Processes).ID, nil
+}
+
+// instanceCloneLastProcess returns the cloning process with the highest ID from the processes,
+// returns an empty process if there is no cloning process
+func instanceCloneLastProcess(
+	processes []models.GetInstanceHistoryProcesses,
+) models.GetInstanceHistoryProcesses {
+	var last models.GetInstanceHistoryProcesses
+	for _, p := range processes {
+		if p.ProcessType.Code == instanceCloneProcessCode && p.ID > last.ID {
+			last = p
+		}
+	}
+
+	return last
+}
+
+// checkInstanceCloneHistory waits until the latest cloning process, which is newer than
+// lastProcessID, is completed in the history of the source instance
+func checkInstanceCloneHistory(
+	ctx context.Context,
+	i *instanceClone,
+	meta interface{},
+	instanceID int,
+	lastProcessID int,
+	timeout time.Duration,
+) error {
 	errCount := 0
 	historyRetry := utils.CustomRetry{
 		InitialDelay: time.Second * 15,
-		RetryDelay:   time.Second * 30,
-		Timeout:      maxTimeout,
+		RetryDelay:   utils.GetPollInterval(meta, time.Second*30),
+		Timeout:      timeout,
 		Cond: func(response interface{}, ResponseErr error) (bool, error) {
 			if ResponseErr != nil {
 				errCount++
@@ -182,18 +344,15 @@ func checkInstanceCloneHistory(ctx context.Context, i *instanceClone, meta inter
 			}
 			errCount = 0
 
-			instanceHistory := response.(models.GetInstanceHistory)
-			for _, processes := range instanceHistory.Processes {
-				if processes.ProcessType.Code == "cloning" {
-					if processes.Status == "success" || processes.Status == "complete" {
-						return true, nil
-					}
-					if processes.Status == "failed" {
-						return false, fmt.Errorf("failed to clone instance")
-					}
-
-					break
-				}
+			process := instanceCloneLastProcess(response.(models.GetInstanceHistory).Processes)
+			if process.ID <= lastProcessID {
+				return false, nil
+			}
+			if process.Status == "success" || process.Status == "complete" {
+				return true, nil
+			}
+			if process.Status == "failed" {
+				return false, fmt.Errorf("failed to clone instance")
 			}
 
 			return false, nil
@@ -226,12 +385,6 @@ func cloneInstance(
 		},
 	}
 	_, err := cloneRetry.Retry(ctx, meta, func(ctx context.Context) (interface{}, error) {
-		val, err := json.Marshal(&req)
-		if err != nil {
-			return nil, err
-		}
-		log.Printf("value: %s", string(val))
-
 		return i.iClient.CloneAnInstance(ctx, sourceID, req)
 	})
 
@@ -250,6 +403,12 @@ func copyInstanceAttribsToClone(
 		return err
 	}
 
+	crossCloud := !utils.IsEmpty(req.Cloud.ID) && req.Cloud.ID != sourceInstance.Instance.Cloud.ID
+	if crossCloud {
+		if err := instanceCloneValidateCloud(ctx, i, req, sourceInstance.Instance.Layout.ID); err != nil {
+			return err
+		}
+	}
 	if utils.IsEmpty(req.Cloud.ID) {
 		req.Cloud.ID = sourceInstance.Instance.Cloud.ID
 	}
@@ -283,9 +442,25 @@ func copyInstanceAttribsToClone(
 	if utils.IsEmpty(req.LayoutSize) {
 		req.LayoutSize = sourceInstance.Instance.Config.Layoutsize
 	}
-	instanceCloneCopyConfig(req, sourceInstance)
+	instanceCloneCopyConfig(req, sourceInstance, crossCloud)
 
+	// volumes are validated at plan time as well, this covers the values unknown while planning
+	if err := instanceCloneValidateVolumes(volumes, sourceInstance.Instance.Volumes); err != nil {
+		return err
+	}
 	req.Volumes = instanceCloneCompareVolume(volumes, sourceInstance.Instance.Volumes)
+	if crossCloud {
+		// datastores of the source volumes are not available in the target cloud
+		schemaVolumes := make(map[string]bool, len(volumes))
+		for _, v := range volumes {
+			schemaVolumes[v["name"].(string)] = true
+		}
+		for j := range req.Volumes {
+			if !schemaVolumes[req.Volumes[j].Name] {
+				req.Volumes[j].DatastoreID = autoDatastore
+			}
+		}
+	}
 	req.Layout = models.IDModel{
 		ID: sourceInstance.Instance.Layout.ID,
 	}
@@ -293,15 +468,64 @@ func copyInstanceAttribsToClone(
 	return nil
 }
 
-func instanceCloneCopyConfig(req *models.CreateInstanceCloneBody, sourceInstance models.GetInstanceResponse) {
-	if utils.IsEmpty(req.Config.ResourcePoolID) {
-		req.Config.ResourcePoolID = sourceInstance.Instance.Config.ResourcePoolID
+// instanceCloneValidateCloud validates the target cloud of a clone, which is different from
+// the cloud of the source instance. Resource pool and folder should be provided for the target
+// cloud and the layout of the source instance should be supported by the target cloud.
+func instanceCloneValidateCloud(
+	ctx context.Context,
+	i *instanceClone,
+	req *models.CreateInstanceCloneBody,
+	layoutID int,
+) error {
+	resourcePoolID, _ := req.Config.ResourcePoolID.Int64()
+	if resourcePoolID == 0 {
+		return fmt.Errorf("error, config.resource_pool_id is required while cloning to a different cloud %d",
+			req.Cloud.ID)
+	}
+	if req.Config.VMwareFolderID == "" {
+		return fmt.Errorf("error, config.folder_code is required while cloning to a different cloud %d",
+			req.Cloud.ID)
+	}
+	if _, err := i.cClient.GetSpecificCloudResourcePool(ctx, req.Cloud.ID, int(resourcePoolID)); err != nil {
+		return fmt.Errorf("error, resource pool %d is not found in the cloud %d: %w",
+			resourcePoolID, req.Cloud.ID, err)
+	}
+
+	cloud, err := i.cClient.GetSpecificCloud(ctx, req.Cloud.ID)
+	if err != nil {
+		return err
 	}
-	if utils.IsEmpty(req.Config.Template) {
-		req.Config.Template = sourceInstance.Instance.Config.Template
+	layout, err := i.lClient.GetSpecificLayout(ctx, layoutID)
+	if err != nil {
+		return err
 	}
-	if utils.IsEmpty(req.Config.VMwareFolderID) {
-		req.Config.VMwareFolderID = sourceInstance.Instance.Config.Vmwarefolderid
+	provisionType := layout.InstanceTypeLayouts.Provisiontype.Code
+	if !strings.EqualFold(cloud.Cloud.Zonetype.Code, provisionType) {
+		return fmt.Errorf("error, layout %d of the source instance with provision type %s is not supported "+
+			"in the cloud %d of type %s", layoutID, provisionType, req.Cloud.ID, cloud.Cloud.Zonetype.Code)
+	}
+
+	return nil
+}
+
+// instanceCloneCopyConfig copies the config of the source instance which are not set for the clone.
+// Resource pool, template and folder belong to the cloud of the source instance, so these are not
+// copied while cloning to a different cloud.
+func instanceCloneCopyConfig(
+	req *models.CreateInstanceCloneBody,
+	sourceInstance models.GetInstanceResponse,
+	crossCloud bool,
+) {
+	if !crossCloud {
+		if utils.IsEmpty(req.Config.ResourcePoolID) {
+			req.Config.ResourcePoolID = sourceInstance.Instance.Config.ResourcePoolID
+		}
+		if utils.IsEmpty(req.Config.Template) {
+			req.Config.Template = sourceInstance.Instance.Config.Template
+		}
+		if utils.IsEmpty(req.Config.VMwareFolderID) {
+			req.Config.VMwareFolderID = sourceInstance.Instance.Config.Vmwarefolderid
+		}
 	}
 	if utils.IsEmpty(req.Config.SmbiosAssetTag) {
 		req.Config.SmbiosAssetTag = sourceInstance.Instance.Config.Smbiosassettag
@@ -0,0 +1,112 @@
+// (C) Copyright 2022 Hewlett Packard Enterprise Development LP
+
+package cmp
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/HewlettPackard/hpegl-vmaas-cmp-go-sdk/pkg/models"
+)
+
+func TestInstanceCloneNewIDs(t *testing.T) {
+	tests := []struct {
+		name        string
+		instances   []models.GetInstanceResponseInstance
+		existingIDs map[int]bool
+		want        []int
+	}{
+		{
+			name:        "Test case 1: no instances",
+			instances:   nil,
+			existingIDs: map[int]bool{1: true},
+			want:        []int{},
+		},
+		{
+			name:        "Test case 2: new instance along with existing instances",
+			instances:   []models.GetInstanceResponseInstance{{ID: 1}, {ID: 2}, {ID: 3}},
+			existingIDs: map[int]bool{1: true, 3: true},
+			want:        []int{2},
+		},
+		{
+			name:        "Test case 3: no existing instances",
+			instances:   []models.GetInstanceResponseInstance{{ID: 4}},
+			existingIDs: map[int]bool{},
+			want:        []int{4},
+		},
+		{
+			name:        "Test case 4: more than one new instance",
+			instances:   []models.GetInstanceResponseInstance{{ID: 1}, {ID: 5}, {ID: 6}},
+			existingIDs: map[int]bool{1: true},
+			want:        []int{5, 6},
+		},
+		{
+			name:        "Test case 5: no new instance",
+			instances:   []models.GetInstanceResponseInstance{{ID: 1}},
+			existingIDs: map[int]bool{1: true},
+			want:        []int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := instanceCloneNewIDs(tt.instances, tt.existingIDs); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("instanceCloneNewIDs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInstanceCloneLastProcess(t *testing.T) {
+	cloning := models.GetInstanceHistoryProcessType{Code: instanceCloneProcessCode}
+	resizing := models.GetInstanceHistoryProcessType{Code: "resize"}
+	tests := []struct {
+		name      string
+		processes []models.GetInstanceHistoryProcesses
+		wantID    int
+	}{
+		{
+			name:      "Test case 1: no processes",
+			processes: nil,
+			wantID:    0,
+		},
+		{
+			name: "Test case 2: no cloning process",
+			processes: []models.GetInstanceHistoryProcesses{
+				{ID: 10, ProcessType: resizing},
+			},
+			wantID: 0,
+		},
+		{
+			name: "Test case 3: latest cloning process listed first",
+			processes: []models.GetInstanceHistoryProcesses{
+				{ID: 12, ProcessType: cloning},
+				{ID: 11, ProcessType: resizing},
+				{ID: 10, ProcessType: cloning},
+			},
+			wantID: 12,
+		},
+		{
+			name: "Test case 4: latest cloning process listed last",
+			processes: []models.GetInstanceHistoryProcesses{
+				{ID: 10, ProcessType: cloning},
+				{ID: 12, ProcessType: cloning},
+			},
+			wantID: 12,
+		},
+		{
+			name: "Test case 5: newer process is not a cloning process",
+			processes: []models.GetInstanceHistoryProcesses{
+				{ID: 13, ProcessType: resizing},
+				{ID: 12, ProcessType: cloning},
+			},
+			wantID: 12,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := instanceCloneLastProcess(tt.processes); got.ID != tt.wantID {
+				t.Errorf("instanceCloneLastProcess() ID = %v, want %v", got.ID, tt.wantID)
+			}
+		})
+	}
+}
@@ -0,0 +1,311 @@
+// (C) Copyright 2022 Hewlett Packard Enterprise Development LP
+
+package cmp
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+
+	"github.com/HewlettPackard/hpegl-vmaas-cmp-go-sdk/pkg/client"
+	"github.com/HewlettPackard/hpegl-vmaas-cmp-go-sdk/pkg/models"
+	"github.com/HewlettPackard/hpegl-vmaas-terraform-resources/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tshihad/tftags"
+)
+
+const (
+	errInstanceMatch = "error, found %d instances matching the given filters, expected exactly one"
+	// API returns only 25 instances by default
+	instanceDSMaxResults = "1000"
+)
+
+// tfInstanceDS holds the computed nested attributes of the instance data source
+type tfInstanceDS struct {
+	History    []models.GetInstanceHistoryProcesses `tf:"history,computed"`
+	Containers []models.GetInstanceContainer        `tf:"containers,computed"`
+}
+
+// instanceDS implements data source for a single instance
+type instanceDS struct {
+	instanceSharedClient
+}
+
+func newInstanceDS(iClient *client.InstancesAPIService, sClient *client.ServersAPIService) *instanceDS {
+	return &instanceDS{
+		instanceSharedClient{
+			iClient: iClient,
+			sClient: sClient,
+		},
+	}
+}
+
+func (i *instanceDS) Read(ctx context.Context, d *utils.Data, meta interface{}) error {
+	setMeta(meta, i.iClient.Client)
+	log.Printf("[DEBUG] Get instance")
+
+	instanceID := d.GetInt("instance_id")
+	// Pre check
+	if err := d.Error(); err != nil {
+		return err
+	}
+
+	var instances []models.GetInstanceResponseInstance
+	if instanceID != 0 {
+		instance, err := i.iClient.GetASpecificInstance(ctx, instanceID)
+		if err != nil {
+			return err
+		}
+		instances = instanceDSFilter(d, []models.GetInstanceResponseInstance{*instance.Instance})
+	} else {
+		var err error
+		instances, err = instanceDSList(ctx, i.iClient, d)
+		if err != nil {
+			return err
+		}
+	}
+	if len(instances) != 1 {
+		return fmt.Errorf(errInstanceMatch, len(instances))
+	}
+	instance := instances[0]
+
+	details, err := instanceDSGetDetails(ctx, i.instanceSharedClient, instance)
+	if err != nil {
+		return err
+	}
+	d.Set("instance_id", instance.ID)
+	for k, v := range details {
+		d.Set(k, v)
+	}
+	d.SetID(instance.ID)
+
+	// post check
+	return d.Error()
+}
+
+// instancesDS implements data source for list of instances
+type instancesDS struct {
+	instanceSharedClient
+}
+
+func newInstancesDS(iClient *client.InstancesAPIService, sClient *client.ServersAPIService) *instancesDS {
+	return &instancesDS{
+		instanceSharedClient{
+			iClient: iClient,
+			sClient: sClient,
+		},
+	}
+}
+
+func (i *instancesDS) Read(ctx context.Context, d *utils.Data, meta interface{}) error {
+	setMeta(meta, i.iClient.Client)
+	log.Printf("[DEBUG] Get instances")
+
+	// Pre check
+	if err := d.Error(); err != nil {
+		return err
+	}
+
+	instances, err := instanceDSList(ctx, i.iClient, d)
+	if err != nil {
+		return err
+	}
+
+	instancesList := make([]interface{}, 0, len(instances))
+	for _, instance := range instances {
+		instanceMap, err := instanceDSGetDetails(ctx, i.instanceSharedClient, instance)
+		if err != nil {
+			return err
+		}
+		instanceMap["id"] = instance.ID
+		if len(instance.ContainerDetails) > 0 {
+			instanceMap["ip_address"] = instance.ContainerDetails[0].IP
+		}
+		instancesList = append(instancesList, instanceMap)
+	}
+	d.Set("instances", instancesList)
+	d.SetID(instanceDSFilterID(d))
+
+	// post check
+	return d.Error()
+}
+
+// instanceDSGetDetails returns the computed attributes of the instance, shared by the instance
+// and the instances data sources
+func instanceDSGetDetails(
+	ctx context.Context,
+	sharedClient instanceSharedClient,
+	instance models.GetInstanceResponseInstance,
+) (map[string]interface{}, error) {
+	details := map[string]interface{}{
+		"name":     instance.Name,
+		"status":   instance.Status,
+		"power":    utils.ParsePowerState(instance.Status),
+		"hostname": instance.HostName,
+		"labels":   instance.Labels,
+		"tags":     instanceImportTags(instance.Tags),
+		"volume":   instanceImportVolumes(instance.Volumes),
+	}
+	if instance.Group != nil {
+		details["group_id"] = instance.Group.ID
+	}
+	if instance.Cloud != nil {
+		details["cloud_id"] = instance.Cloud.ID
+	}
+	if instance.Plan != nil {
+		details["plan_id"] = instance.Plan.ID
+	}
+	if instance.Layout != nil {
+		details["layout_id"] = instance.Layout.ID
+	}
+	if instance.InstanceType != nil {
+		details["instance_type_code"] = instance.InstanceType.Code
+	}
+
+	var serverInterfaces []models.Interfaces
+	if len(instance.ContainerDetails) > 0 {
+		serverID := instance.ContainerDetails[0].Server.ID
+		details["server_id"] = serverID
+		server, err := sharedClient.sClient.GetSpecificServer(ctx, serverID)
+		if err != nil {
+			return nil, err
+		}
+		serverInterfaces = server.Server.Interfaces
+	}
+	details["network"] = instanceImportNetworks(instance.Interfaces, serverInterfaces)
+
+	tfInstance := tfInstanceDS{
+		Containers: instance.ContainerDetails,
+	}
+	history, err := sharedClient.iClient.GetInstanceHistory(ctx, instance.ID)
+	if err != nil {
+		log.Printf("[WARN] Failed to retrieve the history with error %v", err)
+	} else {
+		tfInstance.History = history.Processes
+	}
+	nested := tfInstanceDSMap{}
+	if err := tftags.Set(nested, tfInstance); err != nil {
+		return nil, err
+	}
+	for k, v := range nested {
+		details[k] = v
+	}
+
+	return details, nil
+}
+
+// tfInstanceDSMap collects the attributes set by tftags, so that the nested attributes are
+// converted the same way for the instance and for each item of the instances data source
+type tfInstanceDSMap map[string]interface{}
+
+func (m tfInstanceDSMap) GetOk(key string) (interface{}, bool) {
+	v, ok := m[key]
+
+	return v, ok
+}
+
+func (m tfInstanceDSMap) Set(key string, value interface{}) error {
+	m[key] = value
+
+	return nil
+}
+
+func (m tfInstanceDSMap) Id() string {
+	return ""
+}
+
+func (m tfInstanceDSMap) SetId(string) {}
+
+// instanceDSFilterID returns the data source ID as a hash of the filters, so that data sources
+// with different filters do not share the same ID
+func instanceDSFilterID(d *utils.Data) string {
+	labels := d.GetStringList("labels")
+	sort.Strings(labels)
+	tags := d.GetMap("tags")
+	tagKeys := make([]string, 0, len(tags))
+	for k, v := range tags {
+		tagKeys = append(tagKeys, fmt.Sprintf("%s=%v", k, v))
+	}
+	sort.Strings(tagKeys)
+	filters := fmt.Sprintf("%s|%s|%d|%v|%v", d.GetString("name"), d.GetString("status"),
+		d.GetInt("group_id"), labels, tagKeys)
+
+	return strconv.Itoa(schema.HashString(filters))
+}
+
+// instanceDSList lists all the instances and returns the instances which matches the filters
+func instanceDSList(
+	ctx context.Context,
+	iClient *client.InstancesAPIService,
+	d *utils.Data,
+) ([]models.GetInstanceResponseInstance, error) {
+	query := map[string]string{
+		maxKey: instanceDSMaxResults,
+	}
+	if name := d.GetString("name"); name != "" {
+		query[nameKey] = name
+	}
+	instances, err := iClient.GetAllInstances(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return instanceDSFilter(d, instances.Instances), nil
+}
+
+// instanceDSFilter filters the instances by name, labels, tags, group and status
+func instanceDSFilter(d *utils.Data, instances []models.GetInstanceResponseInstance) []models.GetInstanceResponseInstance {
+	name := d.GetString("name")
+	status := d.GetString("status")
+	groupID := d.GetInt("group_id")
+	labels := d.GetStringList("labels")
+	tags := d.GetMap("tags")
+
+	filtered := make([]models.GetInstanceResponseInstance, 0, len(instances))
+	for _, instance := range instances {
+		if name != "" && instance.Name != name {
+			continue
+		}
+		if status != "" && instance.Status != status {
+			continue
+		}
+		if groupID != 0 && (instance.Group == nil || instance.Group.ID != groupID) {
+			continue
+		}
+		if !instanceDSHasLabels(instance.Labels, labels) || !instanceDSHasTags(instance.Tags, tags) {
+			continue
+		}
+		filtered = append(filtered, instance)
+	}
+
+	return filtered
+}
+
+// instanceDSHasLabels returns true if the instance has all the given labels
+func instanceDSHasLabels(instanceLabels []string, labels []string) bool {
+	labelsMap := make(map[string]bool, len(instanceLabels))
+	for _, l := range instanceLabels {
+		labelsMap[l] = true
+	}
+	for _, l := range labels {
+		if !labelsMap[l] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// instanceDSHasTags returns true if the instance has all the given tags with the same value
+func instanceDSHasTags(instanceTags []models.CreateInstanceBodyTag, tags map[string]interface{}) bool {
+	instanceTagsMap := instanceImportTags(instanceTags)
+	for k, v := range tags {
+		if instanceTagsMap[k] != v {
+			return false
+		}
+	}
+
+	return true
+}
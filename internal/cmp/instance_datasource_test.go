@@ -0,0 +1,68 @@
+// (C) Copyright 2022 Hewlett Packard Enterprise Development LP
+
+package cmp
+
+import (
+	"testing"
+
+	"github.com/HewlettPackard/hpegl-vmaas-terraform-resources/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestInstanceDSFilterID(t *testing.T) {
+	filterSchema := map[string]*schema.Schema{
+		"name":     {Type: schema.TypeString, Optional: true},
+		"status":   {Type: schema.TypeString, Optional: true},
+		"group_id": {Type: schema.TypeInt, Optional: true},
+		"labels":   {Type: schema.TypeList, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+		"tags":     {Type: schema.TypeMap, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+	}
+	getID := func(raw map[string]interface{}) string {
+		return instanceDSFilterID(utils.NewData(schema.TestResourceDataRaw(t, filterSchema, raw)))
+	}
+	tests := []struct {
+		name      string
+		raw       map[string]interface{}
+		otherRaw  map[string]interface{}
+		wantEqual bool
+	}{
+		{
+			name:      "Test case 1: same filters",
+			raw:       map[string]interface{}{"name": "vm", "group_id": 1},
+			otherRaw:  map[string]interface{}{"name": "vm", "group_id": 1},
+			wantEqual: true,
+		},
+		{
+			name:      "Test case 2: different names",
+			raw:       map[string]interface{}{"name": "vm1"},
+			otherRaw:  map[string]interface{}{"name": "vm2"},
+			wantEqual: false,
+		},
+		{
+			name:      "Test case 3: labels in a different order",
+			raw:       map[string]interface{}{"labels": []interface{}{"a", "b"}},
+			otherRaw:  map[string]interface{}{"labels": []interface{}{"b", "a"}},
+			wantEqual: true,
+		},
+		{
+			name:      "Test case 4: different tag values",
+			raw:       map[string]interface{}{"tags": map[string]interface{}{"env": "dev"}},
+			otherRaw:  map[string]interface{}{"tags": map[string]interface{}{"env": "prod"}},
+			wantEqual: false,
+		},
+		{
+			name:      "Test case 5: no filters and a status filter",
+			raw:       map[string]interface{}{},
+			otherRaw:  map[string]interface{}{"status": "running"},
+			wantEqual: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, other := getID(tt.raw), getID(tt.otherRaw)
+			if (got == other) != tt.wantEqual {
+				t.Errorf("instanceDSFilterID() = %v and %v, want equal %v", got, other, tt.wantEqual)
+			}
+		})
+	}
+}
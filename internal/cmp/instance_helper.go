@@ -4,6 +4,7 @@ package cmp
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -14,6 +15,7 @@ import (
 	"github.com/HewlettPackard/hpegl-vmaas-cmp-go-sdk/pkg/models"
 	"github.com/HewlettPackard/hpegl-vmaas-terraform-resources/internal/utils"
 	pkgUtils "github.com/HewlettPackard/hpegl-vmaas-terraform-resources/pkg/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/tshihad/tftags"
 )
 
@@ -41,22 +43,18 @@ func readInstance(ctx context.Context, sharedClient instanceSharedClient, d *uti
 		return err
 	}
 
-	// Assign proper ID for the volume, since response may contains more
-	// volumes than schema, check the name and assign id
-	for i := range tfInstance.Volume {
-		for _, vModel := range instance.Instance.Volumes {
-			if vModel.Name == tfInstance.Volume[i].Name {
-				tfInstance.Volume[i].ID = vModel.ID
-
-				break
-			}
-		}
+	tfInstance.Volume = instanceGetVolumeModel(tfInstance.Volume, instance.Instance.Volumes)
+	// server ID will be missing if the create failed before setting it
+	serverID := d.GetInt("server_id")
+	if serverID == 0 && len(instance.Instance.ContainerDetails) > 0 {
+		serverID = instance.Instance.ContainerDetails[0].Server.ID
+		d.Set("server_id", serverID)
 	}
 	// Invoke all API request in parallel
 	// Get server details
 	serverRetry := &utils.CustomRetry{}
 	serverRetry.RetryParallel(ctx, meta, func(ctx context.Context) (interface{}, error) {
-		return sharedClient.sClient.GetSpecificServer(ctx, d.GetInt("server_id"))
+		return sharedClient.sClient.GetSpecificServer(ctx, serverID)
 	})
 	// get snapshot details
 	snapshotRetry := &utils.CustomRetry{}
@@ -75,8 +73,14 @@ func readInstance(ctx context.Context, sharedClient instanceSharedClient, d *uti
 			return err
 		}
 	}
+	instanceSetDrift(d, instance.Instance)
 
-	tfInstance.Network, err = instanceGetNetworkModel(tfInstance.Network, serverRetry)
+	serverResp, err := serverRetry.Wait()
+	if err != nil {
+		return err
+	}
+	serverInterfaces := serverResp.(models.GetSpecificServerResponse).Server.Interfaces
+	tfInstance.Network, err = instanceGetNetworkModel(tfInstance.Network, instance.Instance.Interfaces, serverInterfaces)
 	if err != nil {
 		return err
 	}
@@ -86,10 +90,16 @@ func readInstance(ctx context.Context, sharedClient instanceSharedClient, d *uti
 	tfInstance.History = instanceGetHistoryModel(historyRetry)
 	tfInstance.Containers = instance.Instance.ContainerDetails
 
+	volumes := d.GetListMap("volume")
+	d.Set("unmanaged_volumes", instanceUnmanagedVolumeNames(instance.Instance.Volumes, volumes))
 	err = tftags.Set(d, tfInstance)
 	if err != nil {
 		return err
 	}
+	instanceSetNetworkAddress(d, serverInterfaces)
+	instanceSetVolumeStorageType(d, volumes)
+	d.Set("primary_ip", instanceGetPrimaryIP(instance.Instance, serverInterfaces))
+	d.Set("servers", instanceGetServers(instance.Instance.ContainerDetails))
 
 	d.SetID(instance.Instance.ID)
 
@@ -97,6 +107,120 @@ func readInstance(ctx context.Context, sharedClient instanceSharedClient, d *uti
 	return d.Error()
 }
 
+// instanceSetDrift reconciles the attributes which can be modified outside terraform,
+// so that terraform plan reflects the actual state of the instance.
+func instanceSetDrift(d *utils.Data, instance *models.GetInstanceResponseInstance) {
+	d.Set("name", instance.Name)
+	d.Set("labels", instance.Labels)
+	d.Set("tags", instanceImportTags(instance.Tags))
+	d.Set("locked", instance.Locked)
+	if instance.Plan != nil {
+		d.Set("plan_id", instance.Plan.ID)
+	}
+	if instance.Group != nil {
+		d.Set("group_id", instance.Group.ID)
+	}
+	// power is optional, so reconcile only if it is already in the state
+	if d.GetString("power") != "" {
+		if power := utils.ParsePowerState(instance.Status); power == utils.PowerOn ||
+			power == utils.PowerOff || power == utils.Suspend {
+			d.Set("power", power)
+		}
+	}
+}
+
+// instanceGetVolumeModel reconciles volumes in the state with the volumes in the API
+// response. Since response may contains more volumes than schema, volumes are matched
+// with the name. Volumes which are not exist in the response are removed from the state.
+func instanceGetVolumeModel(
+	volumes []models.TFInstanceVolume,
+	vModels []models.GetInstanceResponseInstanceVolumes,
+) []models.TFInstanceVolume {
+	newVolumes := make([]models.TFInstanceVolume, 0, len(volumes))
+	for _, v := range volumes {
+		for _, vModel := range vModels {
+			if vModel.Name == v.Name {
+				v.ID = vModel.ID
+				v.Size = vModel.Size
+				v.Root = vModel.RootVolume
+				v.DatastoreID = instanceDatastoreToString(vModel.DatastoreID)
+				newVolumes = append(newVolumes, v)
+
+				break
+			}
+		}
+	}
+
+	return newVolumes
+}
+
+// importInstance builds the state of an existing instance from the API. While importing
+// state contains only the ID, so all the attributes are retrieved from the instance
+// and server details.
+func importInstance(ctx context.Context, sharedClient instanceSharedClient, d *utils.Data) error {
+	id := d.GetID()
+	log.Printf("[INFO] Importing instance with ID %d", id)
+	// Precheck
+	if err := d.Error(); err != nil {
+		return err
+	}
+
+	instance, err := sharedClient.iClient.GetASpecificInstance(ctx, id)
+	if err != nil {
+		return err
+	}
+	if len(instance.Instance.ContainerDetails) == 0 {
+		return fmt.Errorf(errExactMatch, "server")
+	}
+	serverID := instance.Instance.ContainerDetails[0].Server.ID
+	server, err := sharedClient.sClient.GetSpecificServer(ctx, serverID)
+	if err != nil {
+		return err
+	}
+
+	i := instance.Instance
+	d.Set("server_id", serverID)
+	d.Set("name", i.Name)
+	d.Set("hostname", i.HostName)
+	d.Set("env_prefix", i.EnvironmentPrefix)
+	d.Set("delete_on_failure", false)
+	d.Set("deletion_protection", false)
+	d.Set("environment_code", i.InstanceContext)
+	d.Set("labels", i.Labels)
+	d.Set("tags", instanceImportTags(i.Tags))
+	d.Set("evars", instanceImportEvars(i.Evars))
+	d.Set("evar", instanceImportEvar(i.Evars))
+	d.Set("volume", instanceImportVolumes(i.Volumes))
+	d.Set("network", instanceImportNetworks(i.Interfaces, server.Server.Interfaces))
+	if power := utils.ParsePowerState(i.Status); power == utils.PowerOn ||
+		power == utils.PowerOff || power == utils.Suspend {
+		d.Set("power", power)
+	}
+	if i.Cloud != nil {
+		d.Set("cloud_id", i.Cloud.ID)
+	}
+	if i.Group != nil {
+		d.Set("group_id", i.Group.ID)
+	}
+	if i.Layout != nil {
+		d.Set("layout_id", i.Layout.ID)
+	}
+	if i.Plan != nil {
+		d.Set("plan_id", i.Plan.ID)
+	}
+	if i.InstanceType != nil {
+		d.Set("instance_type_code", i.InstanceType.Code)
+	}
+	if i.Config != nil {
+		d.Set("scale", i.Config.Layoutsize)
+		d.Set("power_schedule_id", i.Config.PowerScheduleType)
+		d.Set("config", instanceImportConfig(i.Config))
+	}
+
+	// post check
+	return d.Error()
+}
+
 // Update instance including poweroff, powerOn, restart, suspend
 // changing volumes and instance properties such as labels
 // groups and tags
@@ -189,32 +313,46 @@ func deleteInstance(ctx context.Context, sharedClient instanceSharedClient, d *u
 		return fmt.Errorf("failed to delete instance with error: %s", deleResp.Message)
 	}
 
-	errCount := 0
 	cRetry := utils.CustomRetry{
-		RetryDelay: time.Second * 15,
-		Timeout:    maxTimeout,
-		Cond: func(response interface{}, ResponseErr error) (bool, error) {
-			if ResponseErr != nil {
-				if pkgUtils.GetStatusCode(ResponseErr) == http.StatusNotFound {
-					return true, nil
-				}
-				errCount++
-				if errCount == 3 {
-					return false, err
-				}
-			}
-
-			return false, nil
-		},
+		RetryDelay: utils.GetPollInterval(meta, time.Second*15),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		Cond:       instanceDeleteCond(),
 	}
 	_, err = cRetry.Retry(ctx, meta, func(ctx context.Context) (interface{}, error) {
 		return sharedClient.iClient.GetASpecificInstance(ctx, id)
 	})
+	if err != nil {
+		return fmt.Errorf("failed to wait for the deletion of instance %d with error: %w", id, err)
+	}
 
 	// post check
 	return d.Error()
 }
 
+// instanceDeleteCond returns the retry condition to wait for the deletion of the instance.
+// Deletion completes once the instance is not found. The error is returned if the instance
+// could not be retrieved 3 times in a row.
+func instanceDeleteCond() utils.CondFunc {
+	errCount := 0
+
+	return func(response interface{}, ResponseErr error) (bool, error) {
+		if ResponseErr != nil {
+			if pkgUtils.GetStatusCode(ResponseErr) == http.StatusNotFound {
+				return true, nil
+			}
+			errCount++
+			if errCount == 3 {
+				return false, ResponseErr
+			}
+
+			return false, nil
+		}
+		errCount = 0
+
+		return false, nil
+	}
+}
+
 func instanceGetVolume(volumes []map[string]interface{}) []models.CreateInstanceBodyVolumes {
 	volumesModel := make([]models.CreateInstanceBodyVolumes, 0, len(volumes))
 	for i := range volumes {
@@ -223,6 +361,7 @@ func instanceGetVolume(volumes []map[string]interface{}) []models.CreateInstance
 			Name:        volumes[i]["name"].(string),
 			Size:        volumes[i]["size"].(int),
 			DatastoreID: volumes[i]["datastore_id"],
+			StorageType: volumes[i]["storage_type"].(int),
 		})
 	}
 	volumesModel[0].RootVolume = true
@@ -230,7 +369,8 @@ func instanceGetVolume(volumes []map[string]interface{}) []models.CreateInstance
 	return volumesModel
 }
 
-// Mapping volume data to model
+// Mapping volume data to model. Storage type is sent only for the new volumes, since it can
+// not be changed for an existing volume.
 func instanceResizeVolume(volumes []map[string]interface{}) []models.ResizeInstanceBodyInstanceVolumes {
 	volumesModel := make([]models.ResizeInstanceBodyInstanceVolumes, 0, len(volumes))
 	for i := range volumes {
@@ -240,11 +380,71 @@ func instanceResizeVolume(volumes []map[string]interface{}) []models.ResizeInsta
 			Size:        volumes[i]["size"].(int),
 			DatastoreID: volumes[i]["datastore_id"],
 		})
+		storageType := volumes[i]["storage_type"].(int)
+		if volumesModel[i].ID == utils.JSONNumber(-1) && storageType != 0 {
+			volumesModel[i].StorageType = storageType
+		}
 	}
 
 	return volumesModel
 }
 
+// instanceResizeVolumeModel maps a volume of the instance to the resize model
+func instanceResizeVolumeModel(volume models.GetInstanceResponseInstanceVolumes) models.ResizeInstanceBodyInstanceVolumes {
+	return models.ResizeInstanceBodyInstanceVolumes{
+		ID:          utils.JSONNumber(volume.ID),
+		RootVolume:  volume.RootVolume,
+		Name:        volume.Name,
+		Size:        volume.Size,
+		DatastoreID: volume.DatastoreID,
+	}
+}
+
+// instanceUnmanagedVolumes returns the volumes of the instance which are neither in the previous
+// nor in the new volume attribute, such as the volumes added by hpegl_vmaas_instance_volume.
+// These volumes should be part of the resize request, otherwise resize removes them.
+func instanceUnmanagedVolumes(
+	volumes []models.GetInstanceResponseInstanceVolumes,
+	org, new []map[string]interface{},
+) []models.ResizeInstanceBodyInstanceVolumes {
+	managedIDs := make(map[int]bool, len(org))
+	managedNames := make(map[string]bool, len(org)+len(new))
+	for _, v := range org {
+		managedIDs[v["id"].(int)] = true
+		managedNames[v["name"].(string)] = true
+	}
+	for _, v := range new {
+		managedNames[v["name"].(string)] = true
+	}
+
+	unmanaged := make([]models.ResizeInstanceBodyInstanceVolumes, 0)
+	for _, v := range volumes {
+		if !managedIDs[v.ID] && !managedNames[v.Name] {
+			unmanaged = append(unmanaged, instanceResizeVolumeModel(v))
+		}
+	}
+
+	return unmanaged
+}
+
+// instanceUnmanagedVolumeNames returns the names of the volumes of the instance which are not
+// in the volume attribute, so that the volumes added outside the volume block are reported
+func instanceUnmanagedVolumeNames(
+	volumes []models.GetInstanceResponseInstanceVolumes,
+	schemaVolumes []map[string]interface{},
+) []string {
+	unmanaged := instanceUnmanagedVolumes(volumes, schemaVolumes, nil)
+	names := make([]string, 0, len(unmanaged))
+	for _, v := range unmanaged {
+		names = append(names, v.Name)
+	}
+	if len(names) > 0 {
+		log.Printf("[WARN] Volumes %v of the instance are not managed by the volume block", names)
+	}
+
+	return names
+}
+
 func instanceGetNetwork(networksMap []map[string]interface{}) []models.CreateInstanceBodyNetworkInterfaces {
 	networks := make([]models.CreateInstanceBodyNetworkInterfaces, 0, len(networksMap))
 	for _, n := range networksMap {
@@ -268,7 +468,10 @@ func instanceGetConfig(c map[string]interface{}, isVmware bool) *models.CreateIn
 		Template:       c["template_id"].(int),
 		CreateUser:     c["create_user"].(bool),
 	}
-	if !isVmware {
+	if isVmware {
+		config.VmwareCustomSpec = c["vmware_custom_spec"].(string)
+		config.VmwareDomainName = c["vmware_domain_name"].(string)
+	} else {
 		config.Template = 0
 	}
 
@@ -287,8 +490,11 @@ func instanceGetTags(t map[string]interface{}) []models.CreateInstanceBodyTag {
 	return tags
 }
 
-func instanceGetEvars(evars map[string]interface{}) []models.GetInstanceResponseInstanceEvars {
-	evarModel := make([]models.GetInstanceResponseInstanceEvars, 0, len(evars))
+func instanceGetEvars(
+	evars map[string]interface{},
+	evarList []map[string]interface{},
+) []models.GetInstanceResponseInstanceEvars {
+	evarModel := make([]models.GetInstanceResponseInstanceEvars, 0, len(evars)+len(evarList))
 	for k, v := range evars {
 		evarModel = append(evarModel, models.GetInstanceResponseInstanceEvars{
 			Name:   k,
@@ -297,6 +503,14 @@ func instanceGetEvars(evars map[string]interface{}) []models.GetInstanceResponse
 			Masked: false,
 		})
 	}
+	for _, e := range evarList {
+		evarModel = append(evarModel, models.GetInstanceResponseInstanceEvars{
+			Name:   e["name"].(string),
+			Value:  e["value"].(string),
+			Export: e["export"].(bool),
+			Masked: e["masked"].(bool),
+		})
+	}
 
 	return evarModel
 }
@@ -375,43 +589,91 @@ func instanceDoPowerTask(
 	return err
 }
 
+// instanceCloneCompareVolume merges the schema volumes with the volumes of the source instance.
+// Root volume is sent first and the other source volumes are kept in the source order. Source
+// volumes matching a schema volume by name are resized and moved to the schema datastore. Schema
+// volumes which are not in the source instance are added as new volumes.
 func instanceCloneCompareVolume(
 	vSchemas []map[string]interface{},
 	vModels []models.GetInstanceResponseInstanceVolumes,
 ) []models.CreateInstanceBodyVolumes {
-	newVolumes := make([]models.CreateInstanceBodyVolumes, 0, len(vSchemas))
+	newVolumes := make([]models.CreateInstanceBodyVolumes, 0, len(vSchemas)+len(vModels))
+	schemaVolumes := make(map[string]map[string]interface{}, len(vSchemas))
+	for _, v := range vSchemas {
+		schemaVolumes[v["name"].(string)] = v
+	}
+
+	rootIndex := -1
+	for _, vModel := range vModels {
+		volume := models.CreateInstanceBodyVolumes{
+			ID:          vModel.ID,
+			RootVolume:  vModel.RootVolume,
+			Name:        vModel.Name,
+			Size:        vModel.Size,
+			DatastoreID: vModel.DatastoreID,
+		}
+		if v, ok := schemaVolumes[vModel.Name]; ok {
+			volume.Size = v["size"].(int)
+			volume.DatastoreID = v["datastore_id"]
+			volume.StorageType = v["storage_type"].(int)
+			delete(schemaVolumes, vModel.Name)
+		}
+		if volume.RootVolume && rootIndex == -1 {
+			rootIndex = len(newVolumes)
+		}
+		newVolumes = append(newVolumes, volume)
+	}
 
-	// convert schema volume to model
-	for i := range newVolumes {
+	// add the schema volumes which are not in the source instance, in the schema order
+	for _, v := range vSchemas {
+		if _, ok := schemaVolumes[v["name"].(string)]; !ok {
+			continue
+		}
 		newVolumes = append(newVolumes, models.CreateInstanceBodyVolumes{
 			ID:          -1,
-			Size:        vSchemas[i]["size"].(int),
-			DatastoreID: vSchemas[i]["datastore_id"],
+			Name:        v["name"].(string),
+			Size:        v["size"].(int),
+			DatastoreID: v["datastore_id"],
+			StorageType: v["storage_type"].(int),
 		})
 	}
+	switch {
+	case rootIndex > 0:
+		// move the root volume to the front, keeping the order of the other volumes
+		root := newVolumes[rootIndex]
+		copy(newVolumes[1:rootIndex+1], newVolumes[:rootIndex])
+		newVolumes[0] = root
+	case rootIndex == -1 && len(newVolumes) > 0:
+		newVolumes[0].RootVolume = true
+	}
 
-	// check parent instance have same volume name, if so use same id
-	for _, VModel := range vModels {
-		volumeExist := false
-		for i, v := range newVolumes {
-			if VModel.Name == v.Name {
-				newVolumes[i].ID = VModel.ID
-				volumeExist = true
-			}
+	return newVolumes
+}
+
+// instanceCloneValidateVolumes validates the schema volumes against the volumes of the source
+// instance. Root volume can not be interchanged and source volumes can not be shrunk.
+func instanceCloneValidateVolumes(
+	vSchemas []map[string]interface{},
+	vModels []models.GetInstanceResponseInstanceVolumes,
+) error {
+	sourceVolumes := make(map[string]models.GetInstanceResponseInstanceVolumes, len(vModels))
+	for _, vModel := range vModels {
+		sourceVolumes[vModel.Name] = vModel
+	}
+	for _, v := range vSchemas {
+		name := v["name"].(string)
+		sourceVolume, ok := sourceVolumes[name]
+		if v["root"].(bool) && (!ok || !sourceVolume.RootVolume) {
+			return fmt.Errorf("interchanging the root/primary volume '%s' is not allowed. "+
+				"Please fix your configuration and retry", name)
 		}
-		// if parent instance volume not exist in schema add it in request
-		if !volumeExist {
-			newVolumes = append(newVolumes, models.CreateInstanceBodyVolumes{
-				ID:          VModel.ID,
-				Size:        VModel.Size,
-				DatastoreID: VModel.DatastoreID,
-				Name:        VModel.Name,
-			})
+		if ok && v["size"].(int) < sourceVolume.Size {
+			return fmt.Errorf("size of the volume '%s' can not be less than %d GB, the size in the source instance",
+				name, sourceVolume.Size)
 		}
 	}
-	newVolumes[0].RootVolume = true
 
-	return newVolumes
+	return nil
 }
 
 func createInstanceSnapshot(
@@ -463,11 +725,22 @@ func instanceCheckSnaphotByName(name string, snapshotResp interface{}) int {
 	return -1
 }
 
-func instanceWaitUntilCreated(ctx context.Context, sharedClient instanceSharedClient, meta interface{}, instanceID int) error {
+// instanceWaitUntilCreated waits until the instance is either running or failed. If the instance
+// is pending approval, waits up to approvalTimeout for the approval. Negative approvalTimeout
+// means approval is awaited until the create timeout and zero means fail immediately.
+func instanceWaitUntilCreated(
+	ctx context.Context,
+	sharedClient instanceSharedClient,
+	meta interface{},
+	instanceID int,
+	timeout time.Duration,
+	approvalTimeout time.Duration,
+) error {
 	errCount := 0
+	var approvalStart time.Time
 	cRetry := utils.CustomRetry{
-		Timeout:      maxTimeout,
-		RetryDelay:   time.Second * 15,
+		Timeout:      timeout,
+		RetryDelay:   utils.GetPollInterval(meta, time.Second*15),
 		InitialDelay: time.Minute,
 		Cond: func(response interface{}, err error) (bool, error) {
 			if err != nil {
@@ -491,25 +764,129 @@ func instanceWaitUntilCreated(ctx context.Context, sharedClient instanceSharedCl
 			}
 			errCount = 0
 
-			if instance.Instance.Status == utils.StateFailed ||
-				instance.Instance.Status == utils.StateRunning {
+			switch instance.Instance.Status {
+			case utils.StateFailed, utils.StateRunning:
 				return true, nil
+			case utils.StateDenied:
+				return false, fmt.Errorf("%w, instance ID %d, status message: %s",
+					errInstanceApprovalDenied, instanceID, instance.Instance.StatusMessage)
+			case utils.StatePendingApproval:
+				if approvalStart.IsZero() {
+					approvalStart = time.Now()
+				}
+				if approvalTimeout >= 0 && time.Since(approvalStart) >= approvalTimeout {
+					return false, fmt.Errorf("%w, instance ID %d, status message: %s. "+
+						"Approve the request and run 'terraform untaint' before the next apply",
+						errInstancePendingApproval, instanceID, instance.Instance.StatusMessage)
+				}
+				log.Printf("[INFO] Instance %d is pending approval", instanceID)
 			}
 
 			return false, nil
 		},
 	}
 
-	_, err := cRetry.Retry(ctx, meta, func(ctx context.Context) (interface{}, error) {
-		return sharedClient.iClient.GetASpecificInstance(ctx, instanceID)
+	resp, err := cRetry.Retry(ctx, meta, func(ctx context.Context) (interface{}, error) {
+		instance, err := sharedClient.iClient.GetASpecificInstance(ctx, instanceID)
+		if err == nil && instance.Instance != nil && instance.Instance.Status == utils.StateProvisioning {
+			instanceLogProgress(ctx, sharedClient, instanceID)
+		}
+
+		return instance, err
 	})
 	if err != nil {
 		return err
 	}
+	if instance := resp.(models.GetInstanceResponse); instance.Instance.Status == utils.StateFailed {
+		return instanceGetFailedError(ctx, sharedClient, instance.Instance)
+	}
 
 	return nil
 }
 
+// instanceLogProgress logs the progress of the latest process from the instance history
+func instanceLogProgress(ctx context.Context, sharedClient instanceSharedClient, instanceID int) {
+	history, err := sharedClient.iClient.GetInstanceHistory(ctx, instanceID)
+	if err != nil {
+		log.Printf("[DEBUG] Failed to retrieve the history with error %v", err)
+
+		return
+	}
+	var latest *models.GetInstanceHistoryProcesses
+	for i, p := range history.Processes {
+		if latest == nil || p.ID > latest.ID {
+			latest = &history.Processes[i]
+		}
+	}
+	if latest != nil {
+		log.Printf("[INFO] Instance %d is provisioning, process %d is %s, %.0f%% completed, ETA %d seconds",
+			instanceID, latest.ID, latest.Status, latest.Percent, latest.StatusEta)
+	}
+}
+
+// instanceGetApprovalTimeout returns the approval_timeout as duration. If approval_timeout
+// is not set, returns -1, which makes the wait for approval bounded by the create timeout only.
+func instanceGetApprovalTimeout(d *utils.Data) time.Duration {
+	approvalTimeout := d.GetString("approval_timeout")
+	if approvalTimeout == "" {
+		return -1
+	}
+	timeout, err := time.ParseDuration(approvalTimeout)
+	if err != nil {
+		return -1
+	}
+
+	return timeout
+}
+
+// instanceGetFailedError returns error for the failed instance along with the reason
+// of the latest failed process from the instance history
+func instanceGetFailedError(
+	ctx context.Context,
+	sharedClient instanceSharedClient,
+	instance *models.GetInstanceResponseInstance,
+) error {
+	reason := instance.ErrorMessage
+	if reason == "" {
+		reason = instance.StatusMessage
+	}
+	history, err := sharedClient.iClient.GetInstanceHistory(ctx, instance.ID)
+	if err != nil {
+		log.Printf("[WARN] Failed to retrieve the history with error %v", err)
+	} else {
+		lastID := 0
+		for _, p := range history.Processes {
+			if p.Status == utils.StateFailed && p.Reason != nil && p.ID > lastID {
+				lastID = p.ID
+				reason = fmt.Sprint(p.Reason)
+			}
+		}
+	}
+
+	return fmt.Errorf("%w, instance ID %d, reason: %s", errInstanceFailed, instance.ID, reason)
+}
+
+// instanceHandleCreateFailure deletes the failed instance if delete_on_failure is set and
+// returns back the create error
+func instanceHandleCreateFailure(
+	ctx context.Context,
+	sharedClient instanceSharedClient,
+	d *utils.Data,
+	meta interface{},
+	createErr error,
+) error {
+	if !errors.Is(createErr, errInstanceFailed) || !d.GetBool("delete_on_failure") {
+		return createErr
+	}
+	log.Printf("[INFO] Deleting the failed instance %d", d.GetID())
+	if err := deleteInstance(ctx, sharedClient, d, meta); err != nil {
+		return fmt.Errorf("%v. Deleting the failed instance also failed with error: %w", createErr, err)
+	}
+	d.SetID("")
+
+	return createErr
+}
+
 func instanceGetHistoryModel(retry *utils.CustomRetry) []models.GetInstanceHistoryProcesses {
 	resp, err := retry.Wait()
 	if err != nil {
@@ -538,41 +915,230 @@ func instanceGetResizeNetwork(network []map[string]interface{}) []models.CreateI
 	return nics
 }
 
+// instanceSetServerID sets the servers of all the nodes of the instance from the container
+// details. server_id is the server of the first node.
 func instanceSetServerID(ctx context.Context, d *utils.Data, sharedClient instanceSharedClient) error {
-	servers, err := sharedClient.sClient.GetAllServers(ctx, map[string]string{
-		externalNameKey: d.GetString("name"),
-	})
+	instance, err := sharedClient.iClient.GetASpecificInstance(ctx, d.GetID())
 	if err != nil {
 		return err
 	}
-	if len(servers.Server) != 1 {
+	containers := instance.Instance.ContainerDetails
+	if len(containers) == 0 {
 		return fmt.Errorf(errExactMatch, "server")
 	}
-	err = d.Set("server_id", servers.Server[0].ID)
+	err = d.Set("server_id", containers[0].Server.ID)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return d.Set("servers", instanceGetServers(containers))
+}
+
+// instanceGetServers returns the servers of all the nodes of the instance. API does not
+// flag the primary node, so the first node is considered as the primary node, same as
+// server_id and the network interfaces which are read from the first node.
+func instanceGetServers(containers []models.GetInstanceContainer) []interface{} {
+	servers := make([]interface{}, 0, len(containers))
+	for i, c := range containers {
+		servers = append(servers, map[string]interface{}{
+			"id":      c.Server.ID,
+			"name":    c.Name,
+			"ip":      c.IP,
+			"primary": i == 0,
+		})
+	}
+
+	return servers
 }
 
+// instanceGetNetworkModel reconciles the networks in the state with the instance and
+// server interfaces. Both the interface lists from the API are ordered by position,
+// so interfaces added, removed or modified outside terraform will reflect on the state.
 func instanceGetNetworkModel(
-	networks []models.TFInstanceNetwork, retry *utils.CustomRetry) ([]models.TFInstanceNetwork, error) {
-	resp, err := retry.Wait()
+	networks []models.TFInstanceNetwork,
+	interfaces []models.GetInstanceResponseInstanceInterfaces,
+	serverInterface []models.Interfaces,
+) ([]models.TFInstanceNetwork, error) {
+	newNetworks := make([]models.TFInstanceNetwork, len(serverInterface))
+	for i, s := range serverInterface {
+		if i < len(networks) {
+			newNetworks[i] = networks[i]
+		}
+		newNetworks[i].InternalID = s.ID
+		newNetworks[i].IsPrimary = s.PrimaryInterface
+		newNetworks[i].Name = s.Name
+		if i >= len(interfaces) || interfaces[i].Network == nil {
+			continue
+		}
+		networkID, err := interfaces[i].Network.ID.Int64()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse network ID %q, error: %w", interfaces[i].Network.ID, err)
+		}
+		newNetworks[i].ID = int(networkID)
+		// interface type is optional, so reconcile only if it is already in the state
+		if newNetworks[i].InterfaceID != 0 {
+			newNetworks[i].InterfaceID = interfaces[i].NetworkInterfaceTypeID
+		}
+	}
+
+	return newNetworks, nil
+}
+
+// instanceSetNetworkAddress sets the IP address and IP mode assigned to each network
+// interface. These are not part of the network model, so set them separately.
+func instanceSetNetworkAddress(d *utils.Data, serverInterfaces []models.Interfaces) {
+	networks := d.GetListMap("network")
+	for i := range networks {
+		if i >= len(serverInterfaces) {
+			break
+		}
+		networks[i]["ip_address"] = serverInterfaces[i].IPAddress
+		networks[i]["ip_mode"] = serverInterfaces[i].IPMode
+	}
+	d.Set("network", networks)
+}
+
+// instanceGetPrimaryIP returns the IP address of the instance container. If it is not
+// available yet, returns the IP address of the first network interface of the server.
+func instanceGetPrimaryIP(instance *models.GetInstanceResponseInstance, serverInterfaces []models.Interfaces) string {
+	if len(instance.ContainerDetails) > 0 && instance.ContainerDetails[0].IP != "" {
+		return instance.ContainerDetails[0].IP
+	}
+	if len(serverInterfaces) > 0 {
+		return serverInterfaces[0].IPAddress
+	}
+
+	return ""
+}
+
+// instanceWaitConditionNames are the conditions of wait_for, in the order those are waited for
+var instanceWaitConditionNames = []string{"ip_assigned", "all_nics_addressed", "agent_connected"}
+
+// instanceWaitCondition is an enabled condition of wait_for along with its timeout
+type instanceWaitCondition struct {
+	name    string
+	timeout time.Duration
+}
+
+// instanceWaitForReadiness waits until the conditions in wait_for are satisfied. Conditions are
+// waited for one after another, each until the condition is satisfied or its own timeout is reached.
+func instanceWaitForReadiness(
+	ctx context.Context,
+	sharedClient instanceSharedClient,
+	d *utils.Data,
+	meta interface{},
+	instanceID int,
+) error {
+	waitFor := d.GetListMap("wait_for")
+	if len(waitFor) == 0 {
+		return nil
+	}
+	conditions, err := instanceWaitConditions(waitFor[0])
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	for _, condition := range conditions {
+		condition := condition
+		log.Printf("[INFO] Waiting for %s of the instance %d, timeout %s", condition.name, instanceID, condition.timeout)
+		cRetry := utils.CustomRetry{
+			Timeout:    condition.timeout,
+			RetryDelay: utils.GetPollInterval(meta, time.Second*15),
+			Cond: func(response interface{}, err error) (bool, error) {
+				if err != nil {
+					log.Printf("[DEBUG] Failed to check %s of the instance %d, error: %v", condition.name, instanceID, err)
+
+					return false, nil
+				}
+
+				return response.(bool), nil
+			},
+		}
+		_, err := cRetry.Retry(ctx, meta, func(ctx context.Context) (interface{}, error) {
+			return instanceIsReady(ctx, sharedClient, instanceID, condition.name)
+		})
+		if err != nil {
+			return fmt.Errorf("error while waiting for the instance %d to be ready, %s is not satisfied "+
+				"within its timeout %s: %w", instanceID, condition.name, condition.timeout, err)
+		}
 	}
-	serverInterface := resp.(models.GetSpecificServerResponse).Server.Interfaces
-	if len(serverInterface) != len(networks) {
-		return nil, fmt.Errorf("failed to set network. There is mismatch on created network and the terraform state")
+
+	return nil
+}
+
+// instanceWaitConditions returns the enabled conditions of wait_for. Timeout of a condition
+// defaults to the timeout of wait_for.
+func instanceWaitConditions(waitFor map[string]interface{}) ([]instanceWaitCondition, error) {
+	conditions := make([]instanceWaitCondition, 0, len(instanceWaitConditionNames))
+	for _, name := range instanceWaitConditionNames {
+		if enabled, _ := waitFor[name].(bool); !enabled {
+			continue
+		}
+		timeoutStr, _ := waitFor[name+"_timeout"].(string)
+		if timeoutStr == "" {
+			timeoutStr, _ = waitFor["timeout"].(string)
+		}
+		timeout, err := time.ParseDuration(timeoutStr)
+		if err != nil {
+			return nil, fmt.Errorf("error, invalid timeout of the wait_for condition %s: %w", name, err)
+		}
+		conditions = append(conditions, instanceWaitCondition{name: name, timeout: timeout})
 	}
-	for i, s := range serverInterface {
-		networks[i].InternalID = s.ID
-		networks[i].IsPrimary = s.PrimaryInterface
-		networks[i].Name = s.Name
+
+	return conditions, nil
+}
+
+// instanceIsReady returns true if the wait_for condition is satisfied by the instance
+func instanceIsReady(
+	ctx context.Context,
+	sharedClient instanceSharedClient,
+	instanceID int,
+	condition string,
+) (bool, error) {
+	instance, err := sharedClient.iClient.GetASpecificInstance(ctx, instanceID)
+	if err != nil {
+		return false, err
+	}
+	if len(instance.Instance.ContainerDetails) == 0 {
+		return false, nil
+	}
+	if condition == "ip_assigned" {
+		return instance.Instance.ContainerDetails[0].IP != "", nil
 	}
 
-	return networks, nil
+	server, err := sharedClient.sClient.GetSpecificServer(ctx, instance.Instance.ContainerDetails[0].Server.ID)
+	if err != nil {
+		return false, err
+	}
+	if condition == "agent_connected" {
+		return server.Server.AgentInstalled && server.Server.LastAgentUpdate != nil, nil
+	}
+	if len(server.Server.Interfaces) == 0 {
+		return false, nil
+	}
+	for _, nic := range server.Server.Interfaces {
+		if nic.IPAddress == "" {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// instanceSetVolumeStorageType sets back the storage type of each volume from the previous
+// volumes. Storage type is not part of the volume model and not returned by the API.
+func instanceSetVolumeStorageType(d *utils.Data, prevVolumes []map[string]interface{}) {
+	storageTypes := make(map[string]interface{}, len(prevVolumes))
+	for _, v := range prevVolumes {
+		storageTypes[v["name"].(string)] = v["storage_type"]
+	}
+	volumes := d.GetListMap("volume")
+	for i := range volumes {
+		if storageType, ok := storageTypes[volumes[i]["name"].(string)]; ok {
+			volumes[i]["storage_type"] = storageType
+		}
+	}
+	d.Set("volume", volumes)
 }
 
 func instanceUpdateNetworkVolumePlan(
@@ -583,17 +1149,29 @@ func instanceUpdateNetworkVolumePlan(
 ) error {
 	var resizeReq models.ResizeInstanceBody
 	if d.HasChanged("volume") {
-		volumes := instanceCompareVolumes(d.GetChangedListMap("volume"))
+		orgVolumes, newVolumes := d.GetChangedListMap("volume")
+		volumes := instanceResizeVolume(instanceCompareVolumes(orgVolumes, newVolumes))
+		if err := d.Error(); err != nil {
+			return err
+		}
+
+		// resize request should contain all the volumes of the instance, volume operations of
+		// hpegl_vmaas_instance_volume are locked until the resize is submitted
+		unlock := instanceVolumeLocks.lock(instanceID)
+		defer unlock()
+
+		instance, err := sharedClient.iClient.GetASpecificInstance(ctx, instanceID)
+		if err != nil {
+			return err
+		}
+		volumes = append(volumes, instanceUnmanagedVolumes(instance.Instance.Volumes, orgVolumes, newVolumes)...)
 		resizeReq = models.ResizeInstanceBody{
 			Instance: &models.ResizeInstanceBodyInstance{
 				Plan: &models.ResizeInstanceBodyInstancePlan{
 					ID: d.GetInt("plan_id"),
 				},
 			},
-			Volumes: instanceResizeVolume(volumes),
-		}
-		if err := d.Error(); err != nil {
-			return err
+			Volumes: volumes,
 		}
 	} else if d.HasChanged("plan_id") {
 		resizeReq = models.ResizeInstanceBody{
@@ -621,3 +1199,136 @@ func instanceUpdateNetworkVolumePlan(
 
 	return nil
 }
+
+func instanceImportTags(tags []models.CreateInstanceBodyTag) map[string]interface{} {
+	tagsMap := make(map[string]interface{}, len(tags))
+	for _, t := range tags {
+		tagsMap[t.Name] = t.Value
+	}
+
+	return tagsMap
+}
+
+// instanceImportEvars returns the exported and unmasked environment variables, the same as the
+// ones created from the evars map. Other variables are imported by instanceImportEvar.
+func instanceImportEvars(evars []models.GetInstanceResponseInstanceEvars) map[string]interface{} {
+	evarsMap := make(map[string]interface{}, len(evars))
+	for _, e := range evars {
+		if e.Masked || !e.Export {
+			continue
+		}
+		evarsMap[e.Name] = fmt.Sprint(e.Value)
+	}
+
+	return evarsMap
+}
+
+// instanceImportEvar returns the masked or not exported environment variables, which can be
+// represented only by the evar block
+func instanceImportEvar(evars []models.GetInstanceResponseInstanceEvars) []interface{} {
+	evarList := make([]interface{}, 0, len(evars))
+	for _, e := range evars {
+		if !e.Masked && e.Export {
+			continue
+		}
+		evarList = append(evarList, map[string]interface{}{
+			"name":   e.Name,
+			"value":  fmt.Sprint(e.Value),
+			"masked": e.Masked,
+			"export": e.Export,
+		})
+	}
+
+	return evarList
+}
+
+func instanceImportVolumes(volumes []models.GetInstanceResponseInstanceVolumes) []interface{} {
+	volumesList := make([]interface{}, 0, len(volumes))
+	for _, v := range volumes {
+		volumesList = append(volumesList, map[string]interface{}{
+			"id":           v.ID,
+			"name":         v.Name,
+			"size":         v.Size,
+			"datastore_id": instanceDatastoreToString(v.DatastoreID),
+			"root":         v.RootVolume,
+		})
+	}
+
+	return volumesList
+}
+
+// instanceImportNetworks maps the network interfaces of the instance with the
+// interfaces of the server. Both the lists are ordered by the interface position.
+func instanceImportNetworks(
+	interfaces []models.GetInstanceResponseInstanceInterfaces,
+	serverInterfaces []models.Interfaces,
+) []interface{} {
+	networks := make([]interface{}, 0, len(interfaces))
+	for i, n := range interfaces {
+		network := map[string]interface{}{
+			"interface_id": n.NetworkInterfaceTypeID,
+		}
+		if n.Network != nil {
+			networkID, _ := n.Network.ID.Int64()
+			network["id"] = int(networkID)
+		}
+		if i < len(serverInterfaces) {
+			network["internal_id"] = serverInterfaces[i].ID
+			network["is_primary"] = serverInterfaces[i].PrimaryInterface
+			network["name"] = serverInterfaces[i].Name
+			network["ip_address"] = serverInterfaces[i].IPAddress
+			network["ip_mode"] = serverInterfaces[i].IPMode
+		}
+		networks = append(networks, network)
+	}
+
+	return networks
+}
+
+func instanceImportConfig(c *models.GetInstanceResponseInstanceConfig) []interface{} {
+	resourcePoolID, err := c.ResourcePoolID.Int64()
+	if err != nil {
+		log.Printf("[WARN] Failed to parse resource pool ID %q, error: %v", c.ResourcePoolID, err)
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"resource_pool_id": int(resourcePoolID),
+			"template_id":      c.Template,
+			"no_agent":         instanceParseNoAgent(c.Noagent),
+			"folder_code":      c.Vmwarefolderid,
+			"asset_tag":        c.Smbiosassettag,
+			"create_user":      c.Createuser,
+		},
+	}
+}
+
+// instanceParseNoAgent parses no agent config, since API returns the value
+// either as bool or as string ("on", "true")
+func instanceParseNoAgent(noAgent interface{}) bool {
+	switch n := noAgent.(type) {
+	case bool:
+		return n
+	case string:
+		b, _ := strconv.ParseBool(n)
+
+		return b || n == "on"
+	}
+
+	return false
+}
+
+// instanceDatastoreToString converts datastore ID from the API response to
+// string. JSON numbers are decoded as float64, so format them without exponent.
+func instanceDatastoreToString(datastoreID interface{}) string {
+	switch ds := datastoreID.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(ds, 'f', -1, 64)
+	case string:
+		return ds
+	}
+
+	return fmt.Sprint(datastoreID)
+}
@@ -0,0 +1,649 @@
+// (C) Copyright 2022 Hewlett Packard Enterprise Development LP
+
+package cmp
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/hpegl-vmaas-cmp-go-sdk/pkg/client"
+	"github.com/HewlettPackard/hpegl-vmaas-cmp-go-sdk/pkg/models"
+)
+
+func TestInstanceCloneCompareVolume(t *testing.T) {
+	tests := []struct {
+		name      string
+		vSchemas  []map[string]interface{}
+		vModels   []models.GetInstanceResponseInstanceVolumes
+		wantNames []string
+		wantRoot  string
+	}{
+		{
+			name: "Test case 1: root volume is first in the source",
+			vModels: []models.GetInstanceResponseInstanceVolumes{
+				{ID: 1, Name: "root", RootVolume: true},
+				{ID: 2, Name: "data"},
+			},
+			wantNames: []string{"root", "data"},
+			wantRoot:  "root",
+		},
+		{
+			name: "Test case 2: root volume is not first in the source",
+			vModels: []models.GetInstanceResponseInstanceVolumes{
+				{ID: 2, Name: "data1"},
+				{ID: 3, Name: "data2"},
+				{ID: 1, Name: "root", RootVolume: true},
+			},
+			wantNames: []string{"root", "data1", "data2"},
+			wantRoot:  "root",
+		},
+		{
+			name: "Test case 3: new schema volume is added after the source volumes",
+			vSchemas: []map[string]interface{}{
+				{"name": "new", "size": 10, "datastore_id": "auto", "storage_type": 0},
+			},
+			vModels: []models.GetInstanceResponseInstanceVolumes{
+				{ID: 2, Name: "data"},
+				{ID: 1, Name: "root", RootVolume: true},
+			},
+			wantNames: []string{"root", "data", "new"},
+			wantRoot:  "root",
+		},
+		{
+			name: "Test case 4: no root volume in the source",
+			vModels: []models.GetInstanceResponseInstanceVolumes{
+				{ID: 1, Name: "first"},
+				{ID: 2, Name: "second"},
+			},
+			wantNames: []string{"first", "second"},
+			wantRoot:  "first",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := instanceCloneCompareVolume(tt.vSchemas, tt.vModels)
+			gotNames := make([]string, 0, len(got))
+			for _, v := range got {
+				gotNames = append(gotNames, v.Name)
+			}
+			if !reflect.DeepEqual(gotNames, tt.wantNames) {
+				t.Errorf("instanceCloneCompareVolume() names = %v, want %v", gotNames, tt.wantNames)
+			}
+			if !got[0].RootVolume || got[0].Name != tt.wantRoot {
+				t.Errorf("instanceCloneCompareVolume() first volume = %v, want root volume %s", got[0].Name, tt.wantRoot)
+			}
+		})
+	}
+}
+
+func TestInstanceImportEvars(t *testing.T) {
+	evars := []models.GetInstanceResponseInstanceEvars{
+		{Name: "plain", Value: "a", Export: true},
+		{Name: "secret", Value: "b", Export: true, Masked: true},
+		{Name: "local", Value: 1, Export: false},
+	}
+	tests := []struct {
+		name      string
+		evars     []models.GetInstanceResponseInstanceEvars
+		wantEvars map[string]interface{}
+		wantEvar  []interface{}
+	}{
+		{
+			name:      "Test case 1: no environment variables",
+			evars:     nil,
+			wantEvars: map[string]interface{}{},
+			wantEvar:  []interface{}{},
+		},
+		{
+			name:      "Test case 2: exported and unmasked into evars, others into evar",
+			evars:     evars,
+			wantEvars: map[string]interface{}{"plain": "a"},
+			wantEvar: []interface{}{
+				map[string]interface{}{"name": "secret", "value": "b", "masked": true, "export": true},
+				map[string]interface{}{"name": "local", "value": "1", "masked": false, "export": false},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := instanceImportEvars(tt.evars); !reflect.DeepEqual(got, tt.wantEvars) {
+				t.Errorf("instanceImportEvars() = %v, want %v", got, tt.wantEvars)
+			}
+			if got := instanceImportEvar(tt.evars); !reflect.DeepEqual(got, tt.wantEvar) {
+				t.Errorf("instanceImportEvar() = %v, want %v", got, tt.wantEvar)
+			}
+		})
+	}
+}
+
+func TestInstanceWaitConditions(t *testing.T) {
+	tests := []struct {
+		name    string
+		waitFor map[string]interface{}
+		want    []instanceWaitCondition
+		wantErr bool
+	}{
+		{
+			name:    "Test case 1: no condition enabled",
+			waitFor: map[string]interface{}{"ip_assigned": false, "timeout": "10m"},
+			want:    []instanceWaitCondition{},
+		},
+		{
+			name: "Test case 2: conditions use the default timeout",
+			waitFor: map[string]interface{}{
+				"ip_assigned": true, "agent_connected": true, "agent_connected_timeout": "", "timeout": "10m",
+			},
+			want: []instanceWaitCondition{
+				{name: "ip_assigned", timeout: 10 * time.Minute},
+				{name: "agent_connected", timeout: 10 * time.Minute},
+			},
+		},
+		{
+			name: "Test case 3: condition with its own timeout",
+			waitFor: map[string]interface{}{
+				"all_nics_addressed": true, "all_nics_addressed_timeout": "1h", "timeout": "10m",
+			},
+			want: []instanceWaitCondition{
+				{name: "all_nics_addressed", timeout: time.Hour},
+			},
+		},
+		{
+			name:    "Test case 4: invalid timeout",
+			waitFor: map[string]interface{}{"ip_assigned": true, "timeout": "ten minutes"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := instanceWaitConditions(tt.waitFor)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("instanceWaitConditions() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("instanceWaitConditions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInstanceResizeVolume(t *testing.T) {
+	tests := []struct {
+		name    string
+		volumes []map[string]interface{}
+		want    []models.ResizeInstanceBodyInstanceVolumes
+	}{
+		{
+			name:    "Test case 1: no volumes",
+			volumes: nil,
+			want:    []models.ResizeInstanceBodyInstanceVolumes{},
+		},
+		{
+			name: "Test case 2: storage type of an existing volume is not sent",
+			volumes: []map[string]interface{}{
+				{"id": 10, "name": "root", "size": 20, "datastore_id": "1", "storage_type": 2},
+			},
+			want: []models.ResizeInstanceBodyInstanceVolumes{
+				{ID: "10", Name: "root", Size: 20, DatastoreID: "1"},
+			},
+		},
+		{
+			name: "Test case 3: storage type of a new volume is sent",
+			volumes: []map[string]interface{}{
+				{"id": -1, "name": "data", "size": 5, "datastore_id": "auto", "storage_type": 2},
+			},
+			want: []models.ResizeInstanceBodyInstanceVolumes{
+				{ID: "-1", Name: "data", Size: 5, DatastoreID: "auto", StorageType: 2},
+			},
+		},
+		{
+			name: "Test case 4: new volume without storage type",
+			volumes: []map[string]interface{}{
+				{"id": -1, "name": "data", "size": 5, "datastore_id": "auto", "storage_type": 0},
+			},
+			want: []models.ResizeInstanceBodyInstanceVolumes{
+				{ID: "-1", Name: "data", Size: 5, DatastoreID: "auto"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := instanceResizeVolume(tt.volumes); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("instanceResizeVolume() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInstanceGetVolumeModel(t *testing.T) {
+	tests := []struct {
+		name    string
+		volumes []models.TFInstanceVolume
+		vModels []models.GetInstanceResponseInstanceVolumes
+		want    []models.TFInstanceVolume
+	}{
+		{
+			name:    "Test case 1: volume is updated from the API",
+			volumes: []models.TFInstanceVolume{{Name: "root", Size: 10, DatastoreID: "auto"}},
+			vModels: []models.GetInstanceResponseInstanceVolumes{
+				{ID: 1, Name: "root", Size: 20, RootVolume: true, DatastoreID: float64(5)},
+			},
+			want: []models.TFInstanceVolume{{ID: 1, Name: "root", Size: 20, Root: true, DatastoreID: "5"}},
+		},
+		{
+			name:    "Test case 2: volume removed outside terraform",
+			volumes: []models.TFInstanceVolume{{Name: "root"}, {Name: "data"}},
+			vModels: []models.GetInstanceResponseInstanceVolumes{
+				{ID: 1, Name: "root", Size: 20, RootVolume: true, DatastoreID: "6"},
+			},
+			want: []models.TFInstanceVolume{{ID: 1, Name: "root", Size: 20, Root: true, DatastoreID: "6"}},
+		},
+		{
+			name:    "Test case 3: volume added outside terraform is not added",
+			volumes: []models.TFInstanceVolume{{Name: "root"}},
+			vModels: []models.GetInstanceResponseInstanceVolumes{
+				{ID: 1, Name: "root", Size: 20, RootVolume: true},
+				{ID: 2, Name: "extra", Size: 5},
+			},
+			want: []models.TFInstanceVolume{{ID: 1, Name: "root", Size: 20, Root: true}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := instanceGetVolumeModel(tt.volumes, tt.vModels); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("instanceGetVolumeModel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInstanceUnmanagedVolumes(t *testing.T) {
+	vModels := []models.GetInstanceResponseInstanceVolumes{
+		{ID: 1, Name: "root", Size: 20, RootVolume: true, DatastoreID: "1"},
+		{ID: 2, Name: "data", Size: 5, DatastoreID: "1"},
+		{ID: 3, Name: "extra", Size: 5, DatastoreID: "2"},
+	}
+	tests := []struct {
+		name string
+		org  []map[string]interface{}
+		new  []map[string]interface{}
+		want []models.ResizeInstanceBodyInstanceVolumes
+	}{
+		{
+			name: "Test case 1: all the volumes are managed",
+			org: []map[string]interface{}{
+				{"id": 1, "name": "root"}, {"id": 2, "name": "data"}, {"id": 3, "name": "extra"},
+			},
+			want: []models.ResizeInstanceBodyInstanceVolumes{},
+		},
+		{
+			name: "Test case 2: volume added outside terraform",
+			org:  []map[string]interface{}{{"id": 1, "name": "root"}, {"id": 2, "name": "data"}},
+			want: []models.ResizeInstanceBodyInstanceVolumes{
+				{ID: "3", Name: "extra", Size: 5, DatastoreID: "2"},
+			},
+		},
+		{
+			name: "Test case 3: renamed volume is managed by its ID",
+			org:  []map[string]interface{}{{"id": 1, "name": "root"}, {"id": 2, "name": "old"}},
+			new:  []map[string]interface{}{{"name": "extra"}},
+			want: []models.ResizeInstanceBodyInstanceVolumes{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := instanceUnmanagedVolumes(vModels, tt.org, tt.new); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("instanceUnmanagedVolumes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInstanceGetNetworkModel(t *testing.T) {
+	network := func(id string) *models.GetInstanceResponseInstanceNetwork {
+		return &models.GetInstanceResponseInstanceNetwork{ID: json.Number(id)}
+	}
+	tests := []struct {
+		name             string
+		networks         []models.TFInstanceNetwork
+		interfaces       []models.GetInstanceResponseInstanceInterfaces
+		serverInterfaces []models.Interfaces
+		want             []models.TFInstanceNetwork
+		wantErr          bool
+	}{
+		{
+			name:     "Test case 1: network is updated from the API",
+			networks: []models.TFInstanceNetwork{{ID: 1, InterfaceID: 4}},
+			interfaces: []models.GetInstanceResponseInstanceInterfaces{
+				{Network: network("2"), NetworkInterfaceTypeID: 5},
+			},
+			serverInterfaces: []models.Interfaces{{ID: 11, Name: "eth0", PrimaryInterface: true}},
+			want: []models.TFInstanceNetwork{
+				{ID: 2, InterfaceID: 5, InternalID: 11, Name: "eth0", IsPrimary: true},
+			},
+		},
+		{
+			name:     "Test case 2: interface type not in the state is not reconciled",
+			networks: []models.TFInstanceNetwork{{ID: 1}},
+			interfaces: []models.GetInstanceResponseInstanceInterfaces{
+				{Network: network("1"), NetworkInterfaceTypeID: 5},
+			},
+			serverInterfaces: []models.Interfaces{{ID: 11, Name: "eth0"}},
+			want:             []models.TFInstanceNetwork{{ID: 1, InternalID: 11, Name: "eth0"}},
+		},
+		{
+			name:     "Test case 3: interface added outside terraform",
+			networks: []models.TFInstanceNetwork{{ID: 1}},
+			interfaces: []models.GetInstanceResponseInstanceInterfaces{
+				{Network: network("1")}, {Network: network("3")},
+			},
+			serverInterfaces: []models.Interfaces{{ID: 11, Name: "eth0"}, {ID: 12, Name: "eth1"}},
+			want: []models.TFInstanceNetwork{
+				{ID: 1, InternalID: 11, Name: "eth0"},
+				{ID: 3, InternalID: 12, Name: "eth1"},
+			},
+		},
+		{
+			name:             "Test case 4: interface removed outside terraform",
+			networks:         []models.TFInstanceNetwork{{ID: 1}, {ID: 3}},
+			interfaces:       []models.GetInstanceResponseInstanceInterfaces{{Network: network("1")}},
+			serverInterfaces: []models.Interfaces{{ID: 11, Name: "eth0"}},
+			want:             []models.TFInstanceNetwork{{ID: 1, InternalID: 11, Name: "eth0"}},
+		},
+		{
+			name:             "Test case 5: invalid network ID",
+			networks:         []models.TFInstanceNetwork{{ID: 1}},
+			interfaces:       []models.GetInstanceResponseInstanceInterfaces{{Network: network("abc")}},
+			serverInterfaces: []models.Interfaces{{ID: 11}},
+			wantErr:          true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := instanceGetNetworkModel(tt.networks, tt.interfaces, tt.serverInterfaces)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("instanceGetNetworkModel() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("instanceGetNetworkModel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInstanceCloneValidateVolumes(t *testing.T) {
+	vModels := []models.GetInstanceResponseInstanceVolumes{
+		{ID: 1, Name: "root", Size: 20, RootVolume: true},
+		{ID: 2, Name: "data", Size: 10},
+	}
+	tests := []struct {
+		name     string
+		vSchemas []map[string]interface{}
+		wantErr  bool
+	}{
+		{
+			name:     "Test case 1: no schema volumes",
+			vSchemas: nil,
+			wantErr:  false,
+		},
+		{
+			name: "Test case 2: source volumes are resized and a new volume is added",
+			vSchemas: []map[string]interface{}{
+				{"name": "root", "size": 30, "root": true},
+				{"name": "data", "size": 10, "root": false},
+				{"name": "new", "size": 5, "root": false},
+			},
+			wantErr: false,
+		},
+		{
+			name:     "Test case 3: source volume is shrunk",
+			vSchemas: []map[string]interface{}{{"name": "data", "size": 5, "root": false}},
+			wantErr:  true,
+		},
+		{
+			name:     "Test case 4: root volume is interchanged",
+			vSchemas: []map[string]interface{}{{"name": "data", "size": 10, "root": true}},
+			wantErr:  true,
+		},
+		{
+			name:     "Test case 5: new volume as the root volume",
+			vSchemas: []map[string]interface{}{{"name": "new", "size": 10, "root": true}},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := instanceCloneValidateVolumes(tt.vSchemas, vModels); (err != nil) != tt.wantErr {
+				t.Errorf("instanceCloneValidateVolumes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestInstanceImportVolumes(t *testing.T) {
+	tests := []struct {
+		name    string
+		volumes []models.GetInstanceResponseInstanceVolumes
+		want    []interface{}
+	}{
+		{
+			name:    "Test case 1: no volumes",
+			volumes: nil,
+			want:    []interface{}{},
+		},
+		{
+			name: "Test case 2: volumes with numeric and missing datastore",
+			volumes: []models.GetInstanceResponseInstanceVolumes{
+				{ID: 1, Name: "root", Size: 20, RootVolume: true, DatastoreID: float64(3)},
+				{ID: 2, Name: "data", Size: 5},
+			},
+			want: []interface{}{
+				map[string]interface{}{"id": 1, "name": "root", "size": 20, "datastore_id": "3", "root": true},
+				map[string]interface{}{"id": 2, "name": "data", "size": 5, "datastore_id": "", "root": false},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := instanceImportVolumes(tt.volumes); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("instanceImportVolumes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInstanceImportNetworks(t *testing.T) {
+	tests := []struct {
+		name             string
+		interfaces       []models.GetInstanceResponseInstanceInterfaces
+		serverInterfaces []models.Interfaces
+		want             []interface{}
+	}{
+		{
+			name: "Test case 1: interface with the server interface",
+			interfaces: []models.GetInstanceResponseInstanceInterfaces{
+				{Network: &models.GetInstanceResponseInstanceNetwork{ID: "7"}, NetworkInterfaceTypeID: 4},
+			},
+			serverInterfaces: []models.Interfaces{
+				{ID: 11, Name: "eth0", PrimaryInterface: true, IPAddress: "10.0.0.1", IPMode: "dhcp"},
+			},
+			want: []interface{}{
+				map[string]interface{}{
+					"id": 7, "interface_id": 4, "internal_id": 11, "is_primary": true,
+					"name": "eth0", "ip_address": "10.0.0.1", "ip_mode": "dhcp",
+				},
+			},
+		},
+		{
+			name:       "Test case 2: interface without network and server interface",
+			interfaces: []models.GetInstanceResponseInstanceInterfaces{{NetworkInterfaceTypeID: 4}},
+			want:       []interface{}{map[string]interface{}{"interface_id": 4}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := instanceImportNetworks(tt.interfaces, tt.serverInterfaces); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("instanceImportNetworks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInstanceImportConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config models.GetInstanceResponseInstanceConfig
+		want   []interface{}
+	}{
+		{
+			name: "Test case 1: config with all the values",
+			config: models.GetInstanceResponseInstanceConfig{
+				ResourcePoolID: "3", Template: 5, Noagent: "on", Vmwarefolderid: "group-v1",
+				Smbiosassettag: "tag", Createuser: true,
+			},
+			want: []interface{}{
+				map[string]interface{}{
+					"resource_pool_id": 3, "template_id": 5, "no_agent": true, "folder_code": "group-v1",
+					"asset_tag": "tag", "create_user": true,
+				},
+			},
+		},
+		{
+			name:   "Test case 2: invalid resource pool ID",
+			config: models.GetInstanceResponseInstanceConfig{ResourcePoolID: "pool-1"},
+			want: []interface{}{
+				map[string]interface{}{
+					"resource_pool_id": 0, "template_id": 0, "no_agent": false, "folder_code": "",
+					"asset_tag": "", "create_user": false,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := instanceImportConfig(&tt.config); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("instanceImportConfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInstanceParseNoAgent(t *testing.T) {
+	tests := []struct {
+		name    string
+		noAgent interface{}
+		want    bool
+	}{
+		{name: "Test case 1: nil", noAgent: nil, want: false},
+		{name: "Test case 2: bool true", noAgent: true, want: true},
+		{name: "Test case 3: bool false", noAgent: false, want: false},
+		{name: "Test case 4: string true", noAgent: "true", want: true},
+		{name: "Test case 5: string on", noAgent: "on", want: true},
+		{name: "Test case 6: string off", noAgent: "off", want: false},
+		{name: "Test case 7: unsupported type", noAgent: 1, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := instanceParseNoAgent(tt.noAgent); got != tt.want {
+				t.Errorf("instanceParseNoAgent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInstanceDatastoreToString(t *testing.T) {
+	tests := []struct {
+		name        string
+		datastoreID interface{}
+		want        string
+	}{
+		{name: "Test case 1: nil", datastoreID: nil, want: ""},
+		{name: "Test case 2: float", datastoreID: float64(12), want: "12"},
+		{name: "Test case 3: string", datastoreID: "auto", want: "auto"},
+		{name: "Test case 4: int", datastoreID: 7, want: "7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := instanceDatastoreToString(tt.datastoreID); got != tt.want {
+				t.Errorf("instanceDatastoreToString() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInstanceImportTags(t *testing.T) {
+	tests := []struct {
+		name string
+		tags []models.CreateInstanceBodyTag
+		want map[string]interface{}
+	}{
+		{
+			name: "Test case 1: no tags",
+			tags: nil,
+			want: map[string]interface{}{},
+		},
+		{
+			name: "Test case 2: tags",
+			tags: []models.CreateInstanceBodyTag{{Name: "env", Value: "dev"}, {Name: "team", Value: "a"}},
+			want: map[string]interface{}{"env": "dev", "team": "a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := instanceImportTags(tt.tags); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("instanceImportTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInstanceDeleteCond(t *testing.T) {
+	notFound := client.CustomError{StatusCode: http.StatusNotFound}
+	serverErr := client.CustomError{StatusCode: http.StatusInternalServerError}
+	tests := []struct {
+		name     string
+		errs     []error
+		wantDone bool
+		wantErr  bool
+	}{
+		{
+			name:     "Test case 1: instance is deleted",
+			errs:     []error{nil, notFound},
+			wantDone: true,
+		},
+		{
+			name: "Test case 2: instance is still being deleted",
+			errs: []error{nil, nil, nil},
+		},
+		{
+			name: "Test case 3: errors which are not in a row",
+			errs: []error{serverErr, serverErr, nil, serverErr, serverErr},
+		},
+		{
+			name:    "Test case 4: errors in a row",
+			errs:    []error{nil, serverErr, serverErr, serverErr},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cond := instanceDeleteCond()
+			var done bool
+			var err error
+			for _, respErr := range tt.errs {
+				if done, err = cond(nil, respErr); done || err != nil {
+					break
+				}
+			}
+			if done != tt.wantDone || (err != nil) != tt.wantErr {
+				t.Errorf("instanceDeleteCond() = %v, %v, want %v, wantErr %v", done, err, tt.wantDone, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+// (C) Copyright 2022 Hewlett Packard Enterprise Development LP
+
+package cmp
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/HewlettPackard/hpegl-vmaas-cmp-go-sdk/pkg/client"
+	"github.com/HewlettPackard/hpegl-vmaas-cmp-go-sdk/pkg/models"
+	"github.com/HewlettPackard/hpegl-vmaas-terraform-resources/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	instanceSnapshotRetryDelay = time.Second * 15
+)
+
+// instanceSnapshot implements functions related to snapshots of an instance
+type instanceSnapshot struct {
+	iClient *client.InstancesAPIService
+}
+
+func newInstanceSnapshot(iClient *client.InstancesAPIService) *instanceSnapshot {
+	return &instanceSnapshot{
+		iClient: iClient,
+	}
+}
+
+func (s *instanceSnapshot) Read(ctx context.Context, d *utils.Data, meta interface{}) error {
+	setMeta(meta, s.iClient.Client)
+	id := d.GetID()
+	instanceID := d.GetInt("instance_id")
+	log.Printf("[INFO] Get snapshot %d of the instance %d", id, instanceID)
+	// Precheck
+	if err := d.Error(); err != nil {
+		return err
+	}
+
+	snapshots, err := s.iClient.GetListOfSnapshotsForAnInstance(ctx, instanceID)
+	if err != nil {
+		return err
+	}
+	for _, snapshot := range snapshots.Snapshots {
+		if snapshot.ID == id {
+			instanceSnapshotSetModel(d, snapshot)
+
+			// post check
+			return d.Error()
+		}
+	}
+	// snapshot got deleted outside terraform, remove it from the state
+	log.Printf("[WARN] Snapshot %d of the instance %d not found", id, instanceID)
+	d.SetID("")
+
+	return nil
+}
+
+func (s *instanceSnapshot) Create(ctx context.Context, d *utils.Data, meta interface{}) error {
+	setMeta(meta, s.iClient.Client)
+	instanceID := d.GetInt("instance_id")
+	name := d.GetString("name")
+	req := models.SnapshotBody{
+		Snapshot: &models.SnapshotBodySnapshot{
+			Name:        name,
+			Description: d.GetString("description"),
+		},
+	}
+	// Precheck
+	if err := d.Error(); err != nil {
+		return err
+	}
+
+	// existing snapshots are retrieved before creating, so that the new snapshot can be identified
+	// even if a snapshot with the same name already exists
+	snapshots, err := s.iClient.GetListOfSnapshotsForAnInstance(ctx, instanceID)
+	if err != nil {
+		return err
+	}
+	existingIDs := make(map[int]bool, len(snapshots.Snapshots))
+	for _, snapshot := range snapshots.Snapshots {
+		existingIDs[snapshot.ID] = true
+	}
+
+	log.Printf("[INFO] Creating snapshot %s for the instance %d", name, instanceID)
+	err = createInstanceSnapshot(ctx, instanceSharedClient{iClient: s.iClient}, instanceID, req)
+	if err != nil {
+		return err
+	}
+
+	// snapshot creation is asynchronous, wait until snapshot is listed under the instance
+	retry := utils.CustomRetry{
+		RetryDelay: utils.GetPollInterval(meta, instanceSnapshotRetryDelay),
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Cond: func(response interface{}, ResponseErr error) (bool, error) {
+			if ResponseErr != nil {
+				return false, nil
+			}
+			snapshots := response.(models.ListSnapshotResponse).Snapshots
+
+			return instanceSnapshotNewID(snapshots, name, existingIDs) != -1, nil
+		},
+	}
+	resp, err := retry.Retry(ctx, meta, func(ctx context.Context) (interface{}, error) {
+		return s.iClient.GetListOfSnapshotsForAnInstance(ctx, instanceID)
+	})
+	if err != nil {
+		return err
+	}
+	d.SetID(instanceSnapshotNewID(resp.(models.ListSnapshotResponse).Snapshots, name, existingIDs))
+
+	// post check
+	return d.Error()
+}
+
+// Update is not supported for snapshots, all the attributes will force a new snapshot
+func (s *instanceSnapshot) Update(ctx context.Context, d *utils.Data, meta interface{}) error {
+	return nil
+}
+
+// Delete removes the snapshot from the state. API to delete a snapshot is not supported yet,
+// so the snapshot will remain under the instance.
+func (s *instanceSnapshot) Delete(ctx context.Context, d *utils.Data, meta interface{}) error {
+	log.Printf("[WARN] Deleting snapshot is not supported. Snapshot %s is only removed from the state",
+		d.Id())
+
+	return nil
+}
+
+// instanceSnapshotNewID returns the ID of the snapshot with the given name which is not in
+// existingIDs, returns -1 if there is no such snapshot
+func instanceSnapshotNewID(
+	snapshots []models.ListSnapshotResponseInstance,
+	name string,
+	existingIDs map[int]bool,
+) int {
+	for _, snapshot := range snapshots {
+		if snapshot.Name == name && !existingIDs[snapshot.ID] {
+			return snapshot.ID
+		}
+	}
+
+	return -1
+}
+
+func instanceSnapshotSetModel(d *utils.Data, snapshot models.ListSnapshotResponseInstance) {
+	d.Set("name", snapshot.Name)
+	if snapshot.Description != nil {
+		d.Set("description", fmt.Sprint(snapshot.Description))
+	}
+	d.Set("status", snapshot.Status)
+	d.Set("external_id", snapshot.ExternalID)
+	d.Set("snapshot_created", snapshot.SnapshotCreated)
+	d.Set("currently_active", snapshot.CurrentlyActive)
+}
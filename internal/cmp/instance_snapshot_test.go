@@ -0,0 +1,48 @@
+// (C) Copyright 2022 Hewlett Packard Enterprise Development LP
+
+package cmp
+
+import (
+	"testing"
+
+	"github.com/HewlettPackard/hpegl-vmaas-cmp-go-sdk/pkg/models"
+)
+
+func TestInstanceSnapshotNewID(t *testing.T) {
+	tests := []struct {
+		name        string
+		snapshots   []models.ListSnapshotResponseInstance
+		existingIDs map[int]bool
+		want        int
+	}{
+		{
+			name:        "Test case 1: snapshot is not listed yet",
+			snapshots:   []models.ListSnapshotResponseInstance{{ID: 1, Name: "snap"}},
+			existingIDs: map[int]bool{1: true},
+			want:        -1,
+		},
+		{
+			name: "Test case 2: new snapshot with the name of an existing snapshot",
+			snapshots: []models.ListSnapshotResponseInstance{
+				{ID: 1, Name: "snap"},
+				{ID: 2, Name: "other"},
+				{ID: 3, Name: "snap"},
+			},
+			existingIDs: map[int]bool{1: true, 2: true},
+			want:        3,
+		},
+		{
+			name:        "Test case 3: new snapshot with a different name",
+			snapshots:   []models.ListSnapshotResponseInstance{{ID: 4, Name: "other"}},
+			existingIDs: map[int]bool{},
+			want:        -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := instanceSnapshotNewID(tt.snapshots, "snap", tt.existingIDs); got != tt.want {
+				t.Errorf("instanceSnapshotNewID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,307 @@
+// (C) Copyright 2022 Hewlett Packard Enterprise Development LP
+
+package cmp
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/HewlettPackard/hpegl-vmaas-cmp-go-sdk/pkg/client"
+	"github.com/HewlettPackard/hpegl-vmaas-cmp-go-sdk/pkg/models"
+	"github.com/HewlettPackard/hpegl-vmaas-terraform-resources/internal/utils"
+	pkgUtils "github.com/HewlettPackard/hpegl-vmaas-terraform-resources/pkg/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	instanceVolumeRetryDelay = time.Second * 15
+	errInstanceVolumeExists  = "error, volume %s already exists in the instance %d"
+	errInstanceVolumeShrink  = "error, volume %d can not be shrunk from %d GB to %d GB"
+)
+
+// instanceVolumeLocks serializes the volume operations on the same instance. Resize request
+// contains all the volumes of the instance, so parallel resizes would overwrite each other.
+var instanceVolumeLocks = newInstanceLocks()
+
+// instanceVolume implements functions related to the data volumes of an instance
+type instanceVolume struct {
+	iClient *client.InstancesAPIService
+}
+
+func newInstanceVolume(iClient *client.InstancesAPIService) *instanceVolume {
+	return &instanceVolume{
+		iClient: iClient,
+	}
+}
+
+func (v *instanceVolume) Read(ctx context.Context, d *utils.Data, meta interface{}) error {
+	setMeta(meta, v.iClient.Client)
+	id := d.GetID()
+	instanceID := d.GetInt("instance_id")
+	log.Printf("[INFO] Get volume %d of the instance %d", id, instanceID)
+	// Precheck
+	if err := d.Error(); err != nil {
+		return err
+	}
+
+	instance, err := v.iClient.GetASpecificInstance(ctx, instanceID)
+	if err != nil {
+		if pkgUtils.GetStatusCode(err) == http.StatusNotFound {
+			log.Printf("[WARN] Instance %d not found, removing volume %d from the state", instanceID, id)
+			d.SetID("")
+
+			return nil
+		}
+
+		return err
+	}
+	for _, volume := range instance.Instance.Volumes {
+		if volume.ID == id {
+			d.Set("name", volume.Name)
+			d.Set("size", volume.Size)
+
+			// post check
+			return d.Error()
+		}
+	}
+	// volume got removed outside terraform, remove it from the state
+	log.Printf("[WARN] Volume %d of the instance %d not found", id, instanceID)
+	d.SetID("")
+
+	return nil
+}
+
+func (v *instanceVolume) Create(ctx context.Context, d *utils.Data, meta interface{}) error {
+	setMeta(meta, v.iClient.Client)
+	instanceID := d.GetInt("instance_id")
+	name := d.GetString("name")
+	newVolume := models.ResizeInstanceBodyInstanceVolumes{
+		ID:          "-1",
+		Name:        name,
+		Size:        d.GetInt("size"),
+		DatastoreID: d.GetString("datastore_id"),
+	}
+	if storageType := d.GetInt("storage_type"); storageType != 0 {
+		newVolume.StorageType = storageType
+	}
+	// Precheck
+	if err := d.Error(); err != nil {
+		return err
+	}
+
+	unlock := instanceVolumeLocks.lock(instanceID)
+	defer unlock()
+
+	log.Printf("[INFO] Adding volume %s to the instance %d", name, instanceID)
+	err := v.resize(ctx, instanceID, func(
+		volumes []models.ResizeInstanceBodyInstanceVolumes,
+	) ([]models.ResizeInstanceBodyInstanceVolumes, error) {
+		for _, volume := range volumes {
+			if volume.Name == name {
+				return nil, fmt.Errorf(errInstanceVolumeExists, name, instanceID)
+			}
+		}
+
+		return append(volumes, newVolume), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// volume is added asynchronously, wait until volume is listed under the instance. ID is set
+	// as soon as the volume is listed, so that the volume is kept in the state (as tainted) even
+	// if the resize fails or times out afterwards.
+	err = v.wait(ctx, meta, instanceID, d.Timeout(schema.TimeoutCreate),
+		func(volumes []models.GetInstanceResponseInstanceVolumes) bool {
+			for _, volume := range volumes {
+				if volume.Name == name {
+					d.SetID(volume.ID)
+
+					return true
+				}
+			}
+
+			return false
+		})
+	if err != nil {
+		return err
+	}
+
+	// post check
+	return d.Error()
+}
+
+// Update resizes the volume, all the other attributes will force a new volume
+func (v *instanceVolume) Update(ctx context.Context, d *utils.Data, meta interface{}) error {
+	setMeta(meta, v.iClient.Client)
+	id := d.GetID()
+	instanceID := d.GetInt("instance_id")
+	size := d.GetInt("size")
+	// Precheck
+	if err := d.Error(); err != nil {
+		return err
+	}
+	if !d.HasChanged("size") {
+		return nil
+	}
+
+	unlock := instanceVolumeLocks.lock(instanceID)
+	defer unlock()
+
+	log.Printf("[INFO] Resizing volume %d of the instance %d to %d GB", id, instanceID, size)
+	err := v.resize(ctx, instanceID, func(
+		volumes []models.ResizeInstanceBodyInstanceVolumes,
+	) ([]models.ResizeInstanceBodyInstanceVolumes, error) {
+		for i := range volumes {
+			if volumes[i].ID == utils.JSONNumber(id) {
+				if size < volumes[i].Size {
+					return nil, fmt.Errorf(errInstanceVolumeShrink, id, volumes[i].Size, size)
+				}
+				volumes[i].Size = size
+			}
+		}
+
+		return volumes, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return v.wait(ctx, meta, instanceID, d.Timeout(schema.TimeoutUpdate),
+		func(volumes []models.GetInstanceResponseInstanceVolumes) bool {
+			for _, volume := range volumes {
+				if volume.ID == id {
+					return volume.Size == size
+				}
+			}
+
+			return false
+		})
+}
+
+// Delete detaches the volume from the instance. Volume gets deleted along with detach.
+func (v *instanceVolume) Delete(ctx context.Context, d *utils.Data, meta interface{}) error {
+	setMeta(meta, v.iClient.Client)
+	id := d.GetID()
+	instanceID := d.GetInt("instance_id")
+	// Precheck
+	if err := d.Error(); err != nil {
+		return err
+	}
+
+	unlock := instanceVolumeLocks.lock(instanceID)
+	defer unlock()
+
+	log.Printf("[INFO] Removing volume %d from the instance %d", id, instanceID)
+	err := v.resize(ctx, instanceID, func(
+		volumes []models.ResizeInstanceBodyInstanceVolumes,
+	) ([]models.ResizeInstanceBodyInstanceVolumes, error) {
+		newVolumes := make([]models.ResizeInstanceBodyInstanceVolumes, 0, len(volumes))
+		for _, volume := range volumes {
+			if volume.ID != utils.JSONNumber(id) {
+				newVolumes = append(newVolumes, volume)
+			}
+		}
+
+		return newVolumes, nil
+	})
+	if err != nil {
+		// instance is already deleted along with its volumes
+		if pkgUtils.GetStatusCode(err) == http.StatusNotFound {
+			return nil
+		}
+
+		return err
+	}
+
+	return v.wait(ctx, meta, instanceID, d.Timeout(schema.TimeoutDelete),
+		func(volumes []models.GetInstanceResponseInstanceVolumes) bool {
+			for _, volume := range volumes {
+				if volume.ID == id {
+					return false
+				}
+			}
+
+			return true
+		})
+}
+
+// resize sends resize request with the volumes returned by updateFn. Resize request should
+// contain all the volumes of the instance, so the current volumes are passed to updateFn.
+func (v *instanceVolume) resize(
+	ctx context.Context,
+	instanceID int,
+	updateFn func([]models.ResizeInstanceBodyInstanceVolumes) ([]models.ResizeInstanceBodyInstanceVolumes, error),
+) error {
+	instance, err := v.iClient.GetASpecificInstance(ctx, instanceID)
+	if err != nil {
+		return err
+	}
+
+	volumes := make([]models.ResizeInstanceBodyInstanceVolumes, 0, len(instance.Instance.Volumes))
+	for _, volume := range instance.Instance.Volumes {
+		volumes = append(volumes, instanceResizeVolumeModel(volume))
+	}
+	volumes, err = updateFn(volumes)
+	if err != nil {
+		return err
+	}
+
+	req := &models.ResizeInstanceBody{
+		Instance: &models.ResizeInstanceBodyInstance{
+			Plan: &models.ResizeInstanceBodyInstancePlan{},
+		},
+		Volumes: volumes,
+	}
+	if instance.Instance.Plan != nil {
+		req.Instance.Plan.ID = instance.Instance.Plan.ID
+	}
+	resp, err := v.iClient.ResizeAnInstance(ctx, instanceID, req)
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return fmt.Errorf(successErr, "resizing the instance")
+	}
+
+	return nil
+}
+
+// wait waits until the resize of the instance is completed and cond returns true for
+// the volumes of the instance
+func (v *instanceVolume) wait(
+	ctx context.Context,
+	meta interface{},
+	instanceID int,
+	timeout time.Duration,
+	cond func([]models.GetInstanceResponseInstanceVolumes) bool,
+) error {
+	retry := utils.CustomRetry{
+		RetryDelay:   utils.GetPollInterval(meta, instanceVolumeRetryDelay),
+		InitialDelay: instanceVolumeRetryDelay,
+		Timeout:      timeout,
+		Cond: func(response interface{}, ResponseErr error) (bool, error) {
+			if ResponseErr != nil {
+				return false, nil
+			}
+			instance := response.(models.GetInstanceResponse)
+			if instance.Instance.Status == utils.StateFailed {
+				return false, fmt.Errorf("error, resizing the instance %d failed, status message: %s",
+					instanceID, instance.Instance.StatusMessage)
+			}
+
+			// cond is evaluated on each poll, so that it can record the volumes as soon as listed
+			done := cond(instance.Instance.Volumes)
+
+			return instance.Instance.Status != utils.StateResizing && done, nil
+		},
+	}
+	_, err := retry.Retry(ctx, meta, func(ctx context.Context) (interface{}, error) {
+		return v.iClient.GetASpecificInstance(ctx, instanceID)
+	})
+
+	return err
+}
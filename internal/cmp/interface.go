@@ -6,6 +6,7 @@ import (
 	"context"
 
 	"github.com/HewlettPackard/hpegl-vmaas-terraform-resources/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 // Resource interface implements all the resource operations (CRUD)
@@ -26,6 +27,25 @@ type Resource interface {
 	Delete(context.Context, *utils.Data, interface{}) error
 }
 
+// ResourceImport interface extends Resource with the terraform import
+// operation. Resources which need to build the complete state from the
+// API while importing are expected to implement this.
+type ResourceImport interface {
+	Resource
+	// Import terraform operations. Context and resource data as params.
+	// will return error
+	Import(context.Context, *utils.Data, interface{}) error
+}
+
+// ResourceImportDiff interface extends ResourceImport with the validation
+// of the plan, for the resources which need the API to validate the plan.
+type ResourceImportDiff interface {
+	ResourceImport
+	// DiffValidate terraform customize diff. Context, resource diff and meta
+	// as params. will return error
+	DiffValidate(context.Context, *schema.ResourceDiff, interface{}) error
+}
+
 // DataSource interface wraps read operations which is expected to
 // implement by all data source clients
 type DataSource interface {
@@ -10,6 +10,7 @@ import (
 	"github.com/HewlettPackard/hpegl-vmaas-cmp-go-sdk/pkg/client"
 	"github.com/HewlettPackard/hpegl-vmaas-cmp-go-sdk/pkg/models"
 	"github.com/HewlettPackard/hpegl-vmaas-terraform-resources/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/tshihad/tftags"
 )
 
@@ -89,7 +90,8 @@ func (lb *loadBalancerPool) Create(ctx context.Context, d *utils.Data, meta inte
 	// wait until created
 	retry := &utils.CustomRetry{
 		InitialDelay: time.Second * 15,
-		RetryDelay:   time.Second * 30,
+		RetryDelay:   utils.GetPollInterval(meta, loadBalancerRetryDelay),
+		Timeout:      d.Timeout(schema.TimeoutCreate),
 	}
 	_, err = retry.Retry(ctx, meta, func(ctx context.Context) (interface{}, error) {
 		return lb.lbClient.GetSpecificLBPool(ctx, lbDetails.GetNetworkLoadBalancerResp[0].ID,
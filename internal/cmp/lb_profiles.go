@@ -10,6 +10,7 @@ import (
 	"github.com/HewlettPackard/hpegl-vmaas-cmp-go-sdk/pkg/client"
 	"github.com/HewlettPackard/hpegl-vmaas-cmp-go-sdk/pkg/models"
 	"github.com/HewlettPackard/hpegl-vmaas-terraform-resources/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/tshihad/tftags"
 )
 
@@ -102,7 +103,8 @@ func (lb *loadBalancerProfile) Create(ctx context.Context, d *utils.Data, meta i
 	// wait until created
 	retry := &utils.CustomRetry{
 		InitialDelay: time.Second * 15,
-		RetryDelay:   time.Second * 30,
+		RetryDelay:   utils.GetPollInterval(meta, loadBalancerRetryDelay),
+		Timeout:      d.Timeout(schema.TimeoutCreate),
 	}
 	_, err = retry.Retry(ctx, meta, func(ctx context.Context) (interface{}, error) {
 		return lb.lbClient.GetSpecificLBProfile(ctx, lbDetails.GetNetworkLoadBalancerResp[0].ID, lbProfileResp.LBProfileResp.ID)
@@ -5,10 +5,12 @@ package cmp
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/HewlettPackard/hpegl-vmaas-cmp-go-sdk/pkg/client"
 	"github.com/HewlettPackard/hpegl-vmaas-cmp-go-sdk/pkg/models"
 	"github.com/HewlettPackard/hpegl-vmaas-terraform-resources/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/tshihad/tftags"
 )
 
@@ -106,8 +108,9 @@ func (lb *loadBalancerVirtualServer) Create(ctx context.Context, d *utils.Data,
 	createReq.CreateLBVirtualServersReq.ID = lbVirtualServersResp.CreateLBVirtualServersResp.ID
 	// wait until created
 	retry := &utils.CustomRetry{
-		RetryDelay:   1,
-		InitialDelay: 1,
+		InitialDelay: time.Second * 15,
+		RetryDelay:   utils.GetPollInterval(meta, loadBalancerRetryDelay),
+		Timeout:      d.Timeout(schema.TimeoutCreate),
 	}
 	_, err = retry.Retry(ctx, meta, func(ctx context.Context) (interface{}, error) {
 		return lb.lbClient.GetSpecificLBVirtualServer(ctx, lbDetails.GetNetworkLoadBalancerResp[0].ID, lbVirtualServersResp.CreateLBVirtualServersResp.ID)
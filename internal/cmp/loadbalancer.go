@@ -10,6 +10,7 @@ import (
 	"github.com/HewlettPackard/hpegl-vmaas-cmp-go-sdk/pkg/client"
 	"github.com/HewlettPackard/hpegl-vmaas-cmp-go-sdk/pkg/models"
 	"github.com/HewlettPackard/hpegl-vmaas-terraform-resources/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/tshihad/tftags"
 )
 
@@ -47,7 +48,8 @@ func (lb *loadBalancer) Update(ctx context.Context, d *utils.Data, meta interfac
 
 	retry := &utils.CustomRetry{
 		InitialDelay: time.Second * 15,
-		RetryDelay:   time.Second * 30,
+		RetryDelay:   utils.GetPollInterval(meta, loadBalancerRetryDelay),
+		RetryCount:   loadBalancerUpdateRetryCount,
 	}
 	_, err := retry.Retry(ctx, meta, func(ctx context.Context) (interface{}, error) {
 		return lb.lbClient.UpdateLoadBalancer(ctx, id, updateReq)
@@ -119,7 +121,8 @@ func (lb *loadBalancer) Create(ctx context.Context, d *utils.Data, meta interfac
 	// wait until created
 	retry := &utils.CustomRetry{
 		InitialDelay: time.Second * 15,
-		RetryDelay:   time.Second * 30,
+		RetryDelay:   utils.GetPollInterval(meta, loadBalancerRetryDelay),
+		Timeout:      d.Timeout(schema.TimeoutCreate),
 	}
 	_, err = retry.Retry(ctx, meta, func(ctx context.Context) (interface{}, error) {
 		return lb.lbClient.GetSpecificLoadBalancers(ctx, lbResp.NetworkLoadBalancerResp.ID)
@@ -128,6 +128,9 @@ func (r *resNetwork) Update(ctx context.Context, d *utils.Data, meta interface{}
 }
 
 func (r *resNetwork) Delete(ctx context.Context, d *utils.Data, meta interface{}) error {
+	if err := checkDeletionProtection(d, "network"); err != nil {
+		return err
+	}
 	setMeta(meta, r.rClient.Client)
 	networkID := d.GetID()
 	resp, err := r.nClient.DeleteNetwork(ctx, networkID)
@@ -9,6 +9,7 @@ import (
 	"github.com/HewlettPackard/hpegl-vmaas-cmp-go-sdk/pkg/client"
 	"github.com/HewlettPackard/hpegl-vmaas-cmp-go-sdk/pkg/models"
 	"github.com/HewlettPackard/hpegl-vmaas-terraform-resources/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/tshihad/tftags"
 )
 
@@ -54,10 +55,23 @@ func (r *router) Create(ctx context.Context, d *utils.Data, meta interface{}) er
 	createReq.NetworkRouter.ID = routerResp.ID
 
 	// wait until created
+	errCount := 0
 	retry := &utils.CustomRetry{
-		RetryDelay:   1,
-		InitialDelay: 1,
+		RetryDelay:   utils.GetPollInterval(meta, routerRetryDelay),
+		InitialDelay: routerRetryDelay,
+		Timeout:      d.Timeout(schema.TimeoutCreate),
 		Cond: func(response interface{}, ResponseErr error) (bool, error) {
+			if ResponseErr != nil {
+				errCount++
+				// return the error if the router could not be retrieved 3 times in a row
+				if errCount == 3 {
+					return false, ResponseErr
+				}
+
+				return false, nil
+			}
+			errCount = 0
+
 			return response.(models.GetSpecificRouterResp).NetworkRouter.Status == "ok", nil
 		},
 	}
@@ -101,6 +115,9 @@ func (r *router) Update(ctx context.Context, d *utils.Data, meta interface{}) er
 }
 
 func (r *router) Delete(ctx context.Context, d *utils.Data, meta interface{}) error {
+	if err := checkDeletionProtection(d, "router"); err != nil {
+		return err
+	}
 	routerID := d.GetID()
 	_, err := r.rClient.DeleteRouter(ctx, routerID)
 	if err != nil {
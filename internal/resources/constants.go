@@ -28,10 +28,14 @@ const (
 	DSLBProfile        = "hpegl_vmaas_load_balancer_profile"
 	DSLBPool           = "hpegl_vmaas_load_balancer_pool"
 	DSLBVirtualServer  = "hpegl_vmaas_load_balancer_virtual_server"
+	DSInstance         = "hpegl_vmaas_instance"
+	DSInstances        = "hpegl_vmaas_instances"
 
 	// resource key
 	ResInstance                   = "hpegl_vmaas_instance"
 	ResInstanceClone              = "hpegl_vmaas_instance_clone"
+	ResInstanceSnapshot           = "hpegl_vmaas_instance_snapshot"
+	ResInstanceVolume             = "hpegl_vmaas_instance_volume"
 	ResNetwork                    = "hpegl_vmaas_network"
 	ResRouter                     = "hpegl_vmaas_router"
 	ResLoadBalancer               = "hpegl_vmaas_load_balancer"
@@ -0,0 +1,183 @@
+// (C) Copyright 2022 Hewlett Packard Enterprise Development LP
+
+package resources
+
+import (
+	"context"
+
+	"github.com/HewlettPackard/hpegl-vmaas-terraform-resources/internal/resources/schemas"
+	"github.com/HewlettPackard/hpegl-vmaas-terraform-resources/internal/utils"
+	"github.com/HewlettPackard/hpegl-vmaas-terraform-resources/pkg/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func InstanceData() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"instance_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "ID of the instance",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Name of the instance",
+			},
+			"labels": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				Description: "Labels of the instance. If provided, instance should have all the given labels.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"tags": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Computed:    true,
+				Description: "Tags of the instance. If provided, instance should have all the given tags.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"group_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Group ID of the instance",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Status of the instance",
+			},
+			"power": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Power state of the instance",
+			},
+			"hostname": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Hostname of the instance",
+			},
+			"server_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Server ID of the instance",
+			},
+			"cloud_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Cloud ID of the instance",
+			},
+			"plan_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Plan ID of the instance",
+			},
+			"layout_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Layout ID of the instance",
+			},
+			"instance_type_code": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Instance type code of the instance",
+			},
+			"volume":     schemas.GetInstanceVolumeDSSchema(),
+			"network":    schemas.GetInstanceNetworkDSSchema(),
+			"history":    schemas.GetInstanceHistorySchema(),
+			"containers": schemas.GetInstanceContainerSchema(),
+		},
+		ReadContext: instanceDataReadContext,
+		Description: `The ` + DSInstance + ` data source can be used to discover an existing instance by its ID,
+		name, labels, tags, group or status. Exactly one instance should match the given filters.
+		Details such as server ID, containers, volumes and network interfaces can then be used
+		in other resources.`,
+		SchemaVersion:  0,
+		StateUpgraders: nil,
+	}
+}
+
+func InstancesData() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Filter instances by name",
+			},
+			"labels": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Filter instances having all the given labels",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"tags": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Filter instances having all the given tags",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"group_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Filter instances by group ID",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Filter instances by status",
+			},
+			"instances": schemas.GetInstancesDSSchema(),
+		},
+		ReadContext: instancesDataReadContext,
+		Description: `The ` + DSInstances + ` data source can be used to list the existing instances
+		filtered by name, labels, tags, group or status. Each instance has the same details as the
+		` + DSInstance + ` data source, such as volumes, network interfaces, history and containers.`,
+		SchemaVersion:  0,
+		StateUpgraders: nil,
+	}
+}
+
+func instanceDataReadContext(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, err := client.GetClientFromMetaMap(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data := utils.NewData(d)
+	err = c.CmpClient.DSInstance.Read(ctx, data, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func instancesDataReadContext(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, err := client.GetClientFromMetaMap(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data := utils.NewData(d)
+	err = c.CmpClient.DSInstances.Read(ctx, data, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -52,6 +52,26 @@ func (i *Instance) DiffValidate() error {
 		return err
 	}
 
+	if err := i.instanceTTLDiffValidate(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// instanceTTLDiffValidate rejects any change in expire_days and shutdown_days of an existing
+// instance, since these are applied only while creating the instance
+func (i *Instance) instanceTTLDiffValidate() error {
+	if i.diff.Id() == "" {
+		return nil
+	}
+	for _, param := range []string{"expire_days", "shutdown_days"} {
+		if i.diff.HasChange(param) {
+			return fmt.Errorf("modifying %q of an existing instance is not supported, it is applied only "+
+				"while creating the instance. Revert the change or replace the instance", param)
+		}
+	}
+
 	return nil
 }
 
@@ -100,8 +120,10 @@ func (i *Instance) instanceVolumeDiffValidate() error {
 		newVolMap[tVol["name"].(string)] = tVol["root"].(bool)
 	}
 
+	oldStorageTypes := make(map[string]int)
 	for _, vol := range oldVol.([]interface{}) {
 		tVol := vol.(map[string]interface{})
+		oldStorageTypes[tVol["name"].(string)] = tVol["storage_type"].(int)
 		isRoot, ok := newVolMap[tVol["name"].(string)]
 
 		if tVol["root"].(bool) {
@@ -115,6 +137,16 @@ func (i *Instance) instanceVolumeDiffValidate() error {
 		}
 	}
 
+	// storage type is applied only while creating the volume
+	for _, vol := range newVol.([]interface{}) {
+		tVol := vol.(map[string]interface{})
+		oldStorageType, ok := oldStorageTypes[tVol["name"].(string)]
+		if ok && oldStorageType != tVol["storage_type"].(int) {
+			return fmt.Errorf("modifying storage_type of the existing volume '%s' is not supported. "+
+				"Please fix your configuration and retry", tVol["name"].(string))
+		}
+	}
+
 	return nil
 }
 
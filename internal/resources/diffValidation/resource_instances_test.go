@@ -0,0 +1,71 @@
+//  (C) Copyright 2022 Hewlett Packard Enterprise Development LP
+
+package diffvalidation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestInstanceTTLDiffValidate(t *testing.T) {
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"expire_days":   {Type: schema.TypeInt, Optional: true},
+			"shutdown_days": {Type: schema.TypeInt, Optional: true},
+		},
+		CustomizeDiff: func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+			return NewInstanceValidate(diff).instanceTTLDiffValidate()
+		},
+	}
+	tests := []struct {
+		name    string
+		state   *terraform.InstanceState
+		config  map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name:    "Test case 1: TTL while creating the instance",
+			state:   nil,
+			config:  map[string]interface{}{"expire_days": 10, "shutdown_days": 5},
+			wantErr: false,
+		},
+		{
+			name: "Test case 2: no change in TTL",
+			state: &terraform.InstanceState{
+				ID:         "1",
+				Attributes: map[string]string{"id": "1", "expire_days": "10"},
+			},
+			config:  map[string]interface{}{"expire_days": 10},
+			wantErr: false,
+		},
+		{
+			name: "Test case 3: TTL of an existing instance is changed",
+			state: &terraform.InstanceState{
+				ID:         "1",
+				Attributes: map[string]string{"id": "1", "expire_days": "10"},
+			},
+			config:  map[string]interface{}{"expire_days": 20},
+			wantErr: true,
+		},
+		{
+			name: "Test case 4: TTL is set on an existing instance without TTL",
+			state: &terraform.InstanceState{
+				ID:         "1",
+				Attributes: map[string]string{"id": "1"},
+			},
+			config:  map[string]interface{}{"shutdown_days": 5},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := r.Diff(context.Background(), tt.state, terraform.NewResourceConfigRaw(tt.config), nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("instanceTTLDiffValidate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
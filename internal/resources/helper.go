@@ -4,9 +4,34 @@ package resources
 
 import (
 	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+const defaultResourceTimeout = 20 * time.Minute
+
 // f for format
 func f(format string, val ...interface{}) string {
 	return fmt.Sprintf(format, val...)
 }
+
+// getDefaultTimeouts returns the default timeouts for create, update and delete operations
+func getDefaultTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(defaultResourceTimeout),
+		Update: schema.DefaultTimeout(defaultResourceTimeout),
+		Delete: schema.DefaultTimeout(defaultResourceTimeout),
+	}
+}
+
+// getDeletionProtectionSchema returns the schema of deletion_protection attribute
+func getDeletionProtectionSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+		Description: `If true, the resource can not be deleted. Set this to false and apply
+		before destroying the resource.`,
+	}
+}
@@ -26,18 +26,35 @@ func InstancesClone() *schema.Resource {
 	For creating an instance clone, provide a unique name and all the Mandatory(Required) parameters.
 	All optional parameters will be inherited from parent resource if not provided.`
 
-	instanceCloneSchema.CreateWithoutTimeout = instanceCloneCreateContext
-	instanceCloneSchema.ReadWithoutTimeout = instanceCloneReadContext
-	instanceCloneSchema.UpdateWithoutTimeout = instanceCloneUpdateContext
-	instanceCloneSchema.DeleteWithoutTimeout = instanceCloneDeleteContext
-	instanceCloneSchema.CustomizeDiff = instanceCustomizeDiff
+	instanceCloneSchema.CreateContext = instanceCloneCreateContext
+	instanceCloneSchema.ReadContext = instanceCloneReadContext
+	instanceCloneSchema.UpdateContext = instanceCloneUpdateContext
+	instanceCloneSchema.DeleteContext = instanceCloneDeleteContext
+	instanceCloneSchema.CustomizeDiff = instanceCloneCustomizeDiff
+	instanceCloneSchema.Importer = &schema.ResourceImporter{
+		StateContext: instanceCloneImportContext,
+	}
 
 	return instanceCloneSchema
 }
 
 type instanceCloneResourceObj struct{}
 
-func (*instanceCloneResourceObj) getClient(c *client.Client) cmp.Resource {
+// instanceCloneCustomizeDiff validates the clone same as the instance, along with the
+// volumes of the source instance
+func instanceCloneCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if err := instanceCustomizeDiff(ctx, diff, meta); err != nil {
+		return err
+	}
+	c, err := client.GetClientFromMetaMap(meta)
+	if err != nil {
+		return err
+	}
+
+	return c.CmpClient.InstanceClone.DiffValidate(ctx, diff, meta)
+}
+
+func (*instanceCloneResourceObj) getClient(c *client.Client) cmp.ResourceImport {
 	return c.CmpClient.InstanceClone
 }
 
@@ -49,6 +66,14 @@ func instanceCloneReadContext(ctx context.Context, d *schema.ResourceData, meta
 	return instanceHelperReadContext(ctx, &instanceCloneResourceObj{}, d, meta)
 }
 
+func instanceCloneImportContext(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{},
+) ([]*schema.ResourceData, error) {
+	return instanceHelperImportContext(ctx, &instanceCloneResourceObj{}, d, meta)
+}
+
 func instanceCloneDeleteContext(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	return instanceHelperDeleteContext(ctx, &instanceCloneResourceObj{}, d, meta)
 }
@@ -0,0 +1,117 @@
+// (C) Copyright 2022 Hewlett Packard Enterprise Development LP
+
+package resources
+
+import (
+	"context"
+	"time"
+
+	"github.com/HewlettPackard/hpegl-vmaas-terraform-resources/internal/utils"
+	"github.com/HewlettPackard/hpegl-vmaas-terraform-resources/pkg/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const instanceSnapshotCreateTimeout = 30 * time.Minute
+
+func InstanceSnapshot() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"instance_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the instance. Use " + ResInstance + " or " + ResInstanceClone + " resource to obtain the ID.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the snapshot. Name should be unique for the snapshots of an instance.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Description of the snapshot.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the snapshot.",
+			},
+			"external_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "External ID of the snapshot.",
+			},
+			"snapshot_created": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Date and time at which snapshot got created.",
+			},
+			"currently_active": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Flag which will be true if the snapshot is the current snapshot of the instance.",
+			},
+		},
+		ReadContext:   instanceSnapshotReadContext,
+		CreateContext: instanceSnapshotCreateContext,
+		DeleteContext: instanceSnapshotDeleteContext,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(instanceSnapshotCreateTimeout),
+		},
+		Description: `Instance snapshot resource facilitates creating snapshots of an instance. Multiple
+		snapshots can be created for the same instance.`,
+	}
+}
+
+func instanceSnapshotReadContext(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, err := client.GetClientFromMetaMap(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data := utils.NewData(rd)
+	if err := c.CmpClient.InstanceSnapshot.Read(ctx, data, meta); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func instanceSnapshotCreateContext(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, err := client.GetClientFromMetaMap(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data := utils.NewData(rd)
+	if err := c.CmpClient.InstanceSnapshot.Create(ctx, data, meta); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return instanceSnapshotReadContext(ctx, rd, meta)
+}
+
+func instanceSnapshotDeleteContext(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, err := client.GetClientFromMetaMap(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data := utils.NewData(rd)
+	if err := c.CmpClient.InstanceSnapshot.Delete(ctx, data, meta); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  "Snapshot is removed only from the state",
+			Detail: "Deleting a snapshot is not supported yet. The snapshot " + rd.Id() +
+				" still exists under the instance and should be deleted from HPE GreenLake for private cloud.",
+		},
+	}
+}
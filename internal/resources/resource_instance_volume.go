@@ -0,0 +1,114 @@
+// (C) Copyright 2022 Hewlett Packard Enterprise Development LP
+
+package resources
+
+import (
+	"context"
+
+	"github.com/HewlettPackard/hpegl-vmaas-terraform-resources/internal/resources/validations"
+	"github.com/HewlettPackard/hpegl-vmaas-terraform-resources/internal/utils"
+	"github.com/HewlettPackard/hpegl-vmaas-terraform-resources/pkg/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func InstanceVolume() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"instance_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the instance. Use " + ResInstance + " or " + ResInstanceClone + " resource to obtain the ID.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the volume. Name should be unique for the volumes of an instance.",
+			},
+			"size": {
+				Type:             schema.TypeInt,
+				Required:         true,
+				ValidateDiagFunc: validations.IntAtLeast(1),
+				Description:      "Size of the volume in GB. Volume will be resized on change, shrinking the volume is not supported.",
+			},
+			"datastore_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				Description: `Datastore ID can be obtained from ` + DSDatastore + ` data source.
+				Use the value 'auto' so that the datastore is automatically selected.`,
+			},
+			"storage_type": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Storage type ID of the volume.",
+			},
+		},
+		ReadContext:   instanceVolumeReadContext,
+		CreateContext: instanceVolumeCreateContext,
+		UpdateContext: instanceVolumeUpdateContext,
+		DeleteContext: instanceVolumeDeleteContext,
+		Timeouts:      getDefaultTimeouts(),
+		Description: `Instance volume resource facilitates adding, resizing and removing data volumes
+		of an instance independently of the instance definition.`,
+	}
+}
+
+func instanceVolumeReadContext(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, err := client.GetClientFromMetaMap(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data := utils.NewData(rd)
+	if err := c.CmpClient.InstanceVolume.Read(ctx, data, meta); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func instanceVolumeCreateContext(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, err := client.GetClientFromMetaMap(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data := utils.NewData(rd)
+	if err := c.CmpClient.InstanceVolume.Create(ctx, data, meta); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return instanceVolumeReadContext(ctx, rd, meta)
+}
+
+func instanceVolumeUpdateContext(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, err := client.GetClientFromMetaMap(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data := utils.NewData(rd)
+	if err := c.CmpClient.InstanceVolume.Update(ctx, data, meta); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return instanceVolumeReadContext(ctx, rd, meta)
+}
+
+func instanceVolumeDeleteContext(ctx context.Context, rd *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, err := client.GetClientFromMetaMap(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data := utils.NewData(rd)
+	if err := c.CmpClient.InstanceVolume.Delete(ctx, data, meta); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -44,13 +44,28 @@ func Instances() *schema.Resource {
 			},
 		},
 	}
+	instanceSchema.Schema["expire_days"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ValidateFunc: validation.IntAtLeast(1),
+		Description:  "Number of days after which the instance expires and gets deleted. Can not be changed after creation.",
+	}
+	instanceSchema.Schema["shutdown_days"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ValidateFunc: validation.IntAtLeast(1),
+		Description:  "Number of days after which the instance gets shut down. Can not be changed after creation.",
+	}
 	instanceSchema.Description = `This Instance resource facilitates creating,
 		updating and deleting virtual machines. HPE recommends that you use the VMware as type for provisioning.`
-	instanceSchema.CreateWithoutTimeout = instanceCreateContext
-	instanceSchema.ReadWithoutTimeout = instanceReadContext
-	instanceSchema.DeleteWithoutTimeout = instanceDeleteContext
-	instanceSchema.UpdateWithoutTimeout = instanceUpdateContext
+	instanceSchema.CreateContext = instanceCreateContext
+	instanceSchema.ReadContext = instanceReadContext
+	instanceSchema.DeleteContext = instanceDeleteContext
+	instanceSchema.UpdateContext = instanceUpdateContext
 	instanceSchema.CustomizeDiff = instanceCustomizeDiff
+	instanceSchema.Importer = &schema.ResourceImporter{
+		StateContext: instanceImportContext,
+	}
 
 	return instanceSchema
 }
@@ -63,7 +78,7 @@ func instanceCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta
 	return instance.DiffValidate()
 }
 
-func (i *instanceResourceObj) getClient(c *client.Client) cmp.Resource {
+func (i *instanceResourceObj) getClient(c *client.Client) cmp.ResourceImport {
 	return c.CmpClient.Instance
 }
 
@@ -75,6 +90,10 @@ func instanceReadContext(ctx context.Context, d *schema.ResourceData, meta inter
 	return instanceHelperReadContext(ctx, &instanceResourceObj{}, d, meta)
 }
 
+func instanceImportContext(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	return instanceHelperImportContext(ctx, &instanceResourceObj{}, d, meta)
+}
+
 func instanceDeleteContext(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	return instanceHelperDeleteContext(ctx, &instanceResourceObj{}, d, meta)
 }
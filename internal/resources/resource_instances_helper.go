@@ -8,6 +8,7 @@ import (
 
 	"github.com/HewlettPackard/hpegl-vmaas-terraform-resources/internal/cmp"
 	"github.com/HewlettPackard/hpegl-vmaas-terraform-resources/internal/resources/schemas"
+	"github.com/HewlettPackard/hpegl-vmaas-terraform-resources/internal/resources/validations"
 	"github.com/HewlettPackard/hpegl-vmaas-terraform-resources/internal/utils"
 	"github.com/HewlettPackard/hpegl-vmaas-terraform-resources/pkg/client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -17,14 +18,17 @@ import (
 )
 
 const (
+	// timeouts
+	instanceCreateTimeout = 2 * time.Hour
+	instanceUpdateTimeout = 30 * time.Minute
+	instanceDeleteTimeout = 2 * time.Hour
 	// update
-	instanceUpdateRetryTimeout    = 10 * time.Minute
 	instanceUpdateRetryDelay      = 15 * time.Second
 	instanceUpdateRetryMinTimeout = 15 * time.Second
 )
 
 type resourceObject interface {
-	getClient(*client.Client) cmp.Resource
+	getClient(*client.Client) cmp.ResourceImport
 }
 
 func getInstanceDefaultSchema(isClone bool) *schema.Resource {
@@ -48,6 +52,50 @@ func getInstanceDefaultSchema(isClone bool) *schema.Resource {
 				Computed:    true,
 				Description: f(generalDDesc, "server"),
 			},
+			"servers": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Description: `Servers of all the nodes of the instance, in the order returned by the API.
+				The API does not flag a primary node, so the first server is considered as the primary node.`,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "ID of the server",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the node",
+						},
+						"ip": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "IP address of the node",
+						},
+						"primary": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "true for the first node, which is considered as the primary node",
+						},
+					},
+				},
+			},
+			"primary_ip": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "IP address of the primary network interface of the instance",
+			},
+			"unmanaged_volumes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: `Names of the volumes of the instance which are not listed in the volume block,
+				such as volumes added outside Terraform or by hpegl_vmaas_instance_volume.`,
+			},
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -63,6 +111,7 @@ func getInstanceDefaultSchema(isClone bool) *schema.Resource {
 			"group_id": {
 				Type:        schema.TypeInt,
 				Optional:    isClone,
+				Computed:    isClone,
 				Required:    !isClone,
 				ForceNew:    true,
 				Description: f(generalDDesc, "group"),
@@ -71,6 +120,7 @@ func getInstanceDefaultSchema(isClone bool) *schema.Resource {
 			"plan_id": {
 				Type:        schema.TypeInt,
 				Optional:    isClone,
+				Computed:    isClone,
 				Required:    !isClone,
 				Description: f(generalDDesc, "plan"),
 			},
@@ -115,6 +165,16 @@ func getInstanceDefaultSchema(isClone bool) *schema.Resource {
 							Optional:    true,
 							Description: "name of the interface",
 						},
+						"ip_address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "IP address assigned to the interface",
+						},
+						"ip_mode": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "IP mode of the interface, such as static, dhcp or pool",
+						},
 					},
 				},
 			},
@@ -142,7 +202,13 @@ func getInstanceDefaultSchema(isClone bool) *schema.Resource {
 							Required: true,
 							Description: `Datastore ID can be obtained from hpegl_vmaas_datastore
 							data source. Use the value 'auto' so that the datastore is automatically selected.`,
-							DiffSuppressFunc: utils.SkipField(),
+							DiffSuppressFunc: utils.SuppressAutoDatastore(),
+						},
+						"storage_type": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Description: `Storage type ID of the volume. Applied only while creating the volume,
+							changing it for an existing volume is not allowed.`,
 						},
 						"id": {
 							Computed:    true,
@@ -161,6 +227,7 @@ func getInstanceDefaultSchema(isClone bool) *schema.Resource {
 			"labels": {
 				Type:        schema.TypeList,
 				Optional:    true,
+				Computed:    isClone,
 				Description: "An array of strings for labelling instance.",
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
@@ -169,6 +236,7 @@ func getInstanceDefaultSchema(isClone bool) *schema.Resource {
 			"tags": {
 				Type:        schema.TypeMap,
 				Optional:    true,
+				Computed:    isClone,
 				Description: "A list of key and value pairs used to tag instances of similar type.",
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
@@ -222,6 +290,18 @@ func getInstanceDefaultSchema(isClone bool) *schema.Resource {
 							Description: "Create user",
 							ForceNew:    true,
 						},
+						"vmware_custom_spec": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Description: `Name of the vCenter guest customization specification to be applied
+							while provisioning. Use this for sysprep style customization of Windows guests
+							such as timezone, license and domain join. Applicable only for VMware instances.`,
+						},
+						"vmware_domain_name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Domain name to be set on the guest. Applicable only for VMware instances.",
+						},
 					},
 				},
 			},
@@ -240,6 +320,125 @@ func getInstanceDefaultSchema(isClone bool) *schema.Resource {
 				},
 				Description: "Environment Variables to be added to the provisioned instance.",
 			},
+			"evar": {
+				ForceNew:    true,
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Environment Variable to be added to the provisioned instance, with masking and export options.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name of the environment variable",
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+							Description: "Value of the environment variable",
+						},
+						"masked": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "If true, value of the environment variable will be masked",
+						},
+						"export": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "If true, environment variable will be exported to the instance",
+						},
+					},
+				},
+			},
+			"delete_on_failure": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: `If true, instance will be deleted if the provisioning fails. Terraform will
+				wait until the instance is deleted and then report the error.`,
+			},
+			"deletion_protection": getDeletionProtectionSchema(),
+			"locked": {
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: `If true, the instance is locked in VMaaS and can not be deleted.
+				Lock can only be changed from the VMaaS console.`,
+			},
+			"approval_timeout": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validations.ValidateDuration,
+				Description: `Maximum time to wait for the approval, if the instance is pending approval.
+				Set "0s" to fail immediately once the instance is pending approval. If not set, approval
+				is awaited until the create timeout. Supported format is a duration string such as "30m" or "1h".`,
+			},
+			"wait_for": {
+				Type:             schema.TypeList,
+				Optional:         true,
+				MaxItems:         1,
+				DiffSuppressFunc: utils.SuppressOnUpdate(),
+				Description: `Conditions to wait for after the instance is running, so that the instance
+				is ready to use on completion of create. Conditions are waited for one after another, each
+				with its own timeout. Applicable only while creating the instance, changes are ignored afterwards.`,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ip_assigned": {
+							Type:             schema.TypeBool,
+							Optional:         true,
+							Default:          false,
+							DiffSuppressFunc: utils.SuppressOnUpdate(),
+							Description:      "If true, wait until the instance has an IP address",
+						},
+						"ip_assigned_timeout": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateDiagFunc: validations.ValidateDuration,
+							DiffSuppressFunc: utils.SuppressOnUpdate(),
+							Description:      "Maximum time to wait for ip_assigned. Defaults to timeout.",
+						},
+						"all_nics_addressed": {
+							Type:             schema.TypeBool,
+							Optional:         true,
+							Default:          false,
+							DiffSuppressFunc: utils.SuppressOnUpdate(),
+							Description:      "If true, wait until all the network interfaces have an IP address",
+						},
+						"all_nics_addressed_timeout": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateDiagFunc: validations.ValidateDuration,
+							DiffSuppressFunc: utils.SuppressOnUpdate(),
+							Description:      "Maximum time to wait for all_nics_addressed. Defaults to timeout.",
+						},
+						"agent_connected": {
+							Type:             schema.TypeBool,
+							Optional:         true,
+							Default:          false,
+							DiffSuppressFunc: utils.SuppressOnUpdate(),
+							Description:      "If true, wait until the VMaaS agent is installed and reporting",
+						},
+						"agent_connected_timeout": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateDiagFunc: validations.ValidateDuration,
+							DiffSuppressFunc: utils.SuppressOnUpdate(),
+							Description:      "Maximum time to wait for agent_connected. Defaults to timeout.",
+						},
+						"timeout": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          "10m",
+							ValidateDiagFunc: validations.ValidateDuration,
+							DiffSuppressFunc: utils.SuppressOnUpdate(),
+							Description: `Default maximum time to wait for each condition, such as "10m" or "1h".
+							Used for the conditions without their own timeout.`,
+						},
+					},
+				},
+			},
 			"env_prefix": {
 				ForceNew:    true,
 				Type:        schema.TypeString,
@@ -323,8 +522,10 @@ func getInstanceDefaultSchema(isClone bool) *schema.Resource {
 		SchemaVersion:  0,
 		StateUpgraders: nil,
 		CustomizeDiff:  nil,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(instanceCreateTimeout),
+			Update: schema.DefaultTimeout(instanceUpdateTimeout),
+			Delete: schema.DefaultTimeout(instanceDeleteTimeout),
 		},
 	}
 }
@@ -368,6 +569,25 @@ func instanceHelperReadContext(
 	return nil
 }
 
+func instanceHelperImportContext(
+	ctx context.Context,
+	ro resourceObject,
+	d *schema.ResourceData,
+	meta interface{},
+) ([]*schema.ResourceData, error) {
+	c, err := client.GetClientFromMetaMap(meta)
+	if err != nil {
+		return nil, err
+	}
+
+	data := utils.NewData(d)
+	if err := ro.getClient(c).Import(ctx, data, meta); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func instanceHelperDeleteContext(
 	ctx context.Context,
 	ro resourceObject,
@@ -405,11 +625,11 @@ func instanceHelperUpdateContext(
 	}
 	// Wait for the status to be running
 	updateStateConf := resource.StateChangeConf{
-		Delay:      instanceUpdateRetryDelay,
+		Delay:      utils.GetPollInterval(meta, instanceUpdateRetryDelay),
 		Pending:    []string{utils.StateResizing, utils.StateStopping, utils.StateSuspending, utils.StateRestarting},
 		Target:     []string{utils.StateRunning, utils.StateStopped, utils.StateSuspended},
-		Timeout:    instanceUpdateRetryTimeout,
-		MinTimeout: instanceUpdateRetryMinTimeout,
+		Timeout:    d.Timeout(schema.TimeoutUpdate),
+		MinTimeout: utils.GetPollInterval(meta, instanceUpdateRetryMinTimeout),
 		Refresh: func() (result interface{}, state string, err error) {
 			if err := ro.getClient(c).Read(ctx, data, meta); err != nil {
 				return nil, "", err
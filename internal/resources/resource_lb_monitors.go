@@ -50,6 +50,7 @@ func LoadBalancerMonitor() *schema.Resource {
 		UpdateContext: loadbalancerMonitorUpdateContext,
 		CreateContext: loadbalancerMonitorCreateContext,
 		DeleteContext: loadbalancerMonitorDeleteContext,
+		Timeouts:      getDefaultTimeouts(),
 		CustomizeDiff: monitorCustomDiff,
 		Description: `loadbalancer Monitor resource facilitates creating,updating
 		and deleting NSX-T Network Load Balancers.`,
@@ -111,6 +111,7 @@ func LoadBalancerPools() *schema.Resource {
 		UpdateContext: loadbalancerPoolReadContext,
 		CreateContext: loadbalancerPoolCreateContext,
 		DeleteContext: loadbalancerPoolDeleteContext,
+		Timeouts:      getDefaultTimeouts(),
 		Description: `loadbalancer Pool resource facilitates creating,
 		and deleting NSX-T  Network Load Balancers.`,
 	}
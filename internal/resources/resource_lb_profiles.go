@@ -114,6 +114,7 @@ func LoadBalancerProfiles() *schema.Resource {
 		UpdateContext: loadbalancerProfileReadContext,
 		CreateContext: loadbalancerProfileCreateContext,
 		DeleteContext: loadbalancerProfileDeleteContext,
+		Timeouts:      getDefaultTimeouts(),
 		Description: `loadbalancer Profile resource facilitates creating,
 		and deleting NSX-T  Network Load Balancers.`,
 	}
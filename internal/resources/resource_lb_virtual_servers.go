@@ -91,6 +91,7 @@ func LoadBalancerVirtualServers() *schema.Resource {
 		UpdateContext: loadbalancerVirtualServerReadContext,
 		CreateContext: loadbalancerVirtualServerCreateContext,
 		DeleteContext: loadbalancerVirtualServerDeleteContext,
+		Timeouts:      getDefaultTimeouts(),
 		Description: `loadbalancer Virtual Server resource facilitates creating,
 		and deleting NSX-T  Network Load Balancers.`,
 	}
@@ -117,6 +117,7 @@ func LoadBalancer() *schema.Resource {
 		UpdateContext: LoadbalancerUpdateContext,
 		CreateContext: LoadbalancerCreateContext,
 		DeleteContext: LoadbalancerDeleteContext,
+		Timeouts:      getDefaultTimeouts(),
 		Description: `loadbalancer resource facilitates creating, updating
 		and deleting NSX-T  Network Load Balancers.`,
 	}
@@ -204,6 +204,7 @@ func Network() *schema.Resource {
 				Required:    true,
 				Description: "Transport Zone ID. Use " + DSTransportZone + " Data source's `provider_id` here.",
 			},
+			"deletion_protection": getDeletionProtectionSchema(),
 		},
 		SchemaVersion: 0,
 		Importer: &schema.ResourceImporter{
@@ -213,6 +214,7 @@ func Network() *schema.Resource {
 		CreateContext: resNetworkCreateContext,
 		UpdateContext: resNetworkUpdateContext,
 		DeleteContext: resNetworkDeleteContext,
+		Timeouts:      getDefaultTimeouts(),
 		Description: `Network resource facilitates creating,
 		updating and deleting NSX-T Networks.`,
 	}
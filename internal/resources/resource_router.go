@@ -39,6 +39,7 @@ func Router() *schema.Resource {
 				Description: "Enables or disables the network router",
 				Default:     true,
 			},
+			"deletion_protection": getDeletionProtectionSchema(),
 			"network_server_id": {
 				Type:        schema.TypeInt,
 				Computed:    true,
@@ -81,6 +82,7 @@ func Router() *schema.Resource {
 		CreateContext: routerCreateContext,
 		UpdateContext: routerUpdateContext,
 		DeleteContext: routerDeleteContext,
+		Timeouts:      getDefaultTimeouts(),
 		CustomizeDiff: routerCustomDiff,
 		Description: `Router resource facilitates creating,
 		updating and deleting NSX-T Tier0/Tier1 Network Routers.`,
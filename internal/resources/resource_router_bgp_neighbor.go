@@ -124,6 +124,7 @@ func RouterBgpNeighbor() *schema.Resource {
 		CreateContext: routerBgpNeighborCreateContext,
 		UpdateContext: routerBgpNeighborUpdateContext,
 		DeleteContext: routerBgpNeighborDeleteContext,
+		Timeouts:      getDefaultTimeouts(),
 		Description: `Router Bgp Neighbor resource facilitates creating,
 		updating and deleting NSX-T Network Router BGP Neighbors.`,
 	}
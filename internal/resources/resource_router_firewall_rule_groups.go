@@ -52,6 +52,7 @@ func RouterFirewallRuleGroup() *schema.Resource {
 		CreateContext: routerFirewallRuleGroupCreateContext,
 		UpdateContext: routerFirewallRuleGroupUpdateContext,
 		DeleteContext: routerFirewallRuleGroupDeleteContext,
+		Timeouts:      getDefaultTimeouts(),
 	}
 }
 
@@ -119,6 +119,7 @@ func RouterNatRule() *schema.Resource {
 		CreateContext: routerNatRuleCreateContext,
 		UpdateContext: routerNatRuleUpdateContext,
 		DeleteContext: routerNatRuleDeleteContext,
+		Timeouts:      getDefaultTimeouts(),
 		CustomizeDiff: routerNatCustomDiff,
 		Description: `Router NAT rule resource facilitates creating,
 		updating and deleting NSX-T Network Router NAT rules.`,
@@ -97,6 +97,7 @@ func RouterRoute() *schema.Resource {
 		CreateContext: routerRouteCreateContext,
 		UpdateContext: routerRouteUpdateContext,
 		DeleteContext: routerRouteDeleteContext,
+		Timeouts:      getDefaultTimeouts(),
 		Description: `Router route resource facilitates creating,
 		updating and deleting NSX-T Network Router routes.`,
 	}
@@ -98,6 +98,84 @@ func GetInstanceContainerSchema() *schema.Schema {
 	}
 }
 
+func GetInstanceVolumeDSSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: `Volumes of the instance`,
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id":           computedInt(),
+				"name":         computedString(),
+				"size":         computedInt(),
+				"datastore_id": computedString(),
+				"root":         computedBool(),
+			},
+		},
+	}
+}
+
+func GetInstanceNetworkDSSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: `Network interfaces of the instance along with the assigned IP address`,
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id":           computedInt(),
+				"interface_id": computedInt(),
+				"internal_id":  computedInt(),
+				"is_primary":   computedBool(),
+				"name":         computedString(),
+				"ip_address":   computedString(),
+				"ip_mode":      computedString(),
+			},
+		},
+	}
+}
+
+func GetInstancesDSSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: `List of instances matching the filters`,
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id":                 computedInt(),
+				"name":               computedString(),
+				"status":             computedString(),
+				"power":              computedString(),
+				"hostname":           computedString(),
+				"server_id":          computedInt(),
+				"ip_address":         computedString(),
+				"group_id":           computedInt(),
+				"cloud_id":           computedInt(),
+				"plan_id":            computedInt(),
+				"layout_id":          computedInt(),
+				"instance_type_code": computedString(),
+				"volume":             GetInstanceVolumeDSSchema(),
+				"network":            GetInstanceNetworkDSSchema(),
+				"history":            GetInstanceHistorySchema(),
+				"containers":         GetInstanceContainerSchema(),
+				"labels": {
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"tags": {
+					Type:     schema.TypeMap,
+					Computed: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+			},
+		},
+	}
+}
+
 func computedString() *schema.Schema {
 	return &schema.Schema{
 		Computed: true,
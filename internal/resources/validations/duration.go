@@ -0,0 +1,24 @@
+// (C) Copyright 2022 Hewlett Packard Enterprise Development LP
+
+package validations
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// ValidateDuration validates duration strings like 30m or 1h30m
+func ValidateDuration(i interface{}, p cty.Path) diag.Diagnostics {
+	v, ok := i.(string)
+	if !ok {
+		return diag.Errorf("expected type of duration to be string")
+	}
+	if _, err := time.ParseDuration(v); err != nil {
+		return diag.FromErr(fmt.Errorf("%q is not a valid duration: %w", v, err))
+	}
+
+	return nil
+}
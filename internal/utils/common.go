@@ -6,6 +6,9 @@ import (
 	"encoding/json"
 	"reflect"
 	"strconv"
+	"time"
+
+	"github.com/HewlettPackard/hpegl-vmaas-terraform-resources/pkg/constants"
 )
 
 func JSONNumber(in interface{}) json.Number {
@@ -39,3 +42,21 @@ func ParsePowerState(state string) string {
 
 	return state
 }
+
+// pollIntervalGetter is implemented by the provider client stored in the meta, which holds
+// the provider level delay between polls while waiting on long running operations
+type pollIntervalGetter interface {
+	GetPollInterval() time.Duration
+}
+
+// GetPollInterval returns the poll interval configured for the provider of meta, otherwise
+// returns defaultInterval
+func GetPollInterval(meta interface{}, defaultInterval time.Duration) time.Duration {
+	if m, ok := meta.(map[string]interface{}); ok {
+		if c, ok := m[constants.ClientMapKey].(pollIntervalGetter); ok && c.GetPollInterval() > 0 {
+			return c.GetPollInterval()
+		}
+	}
+
+	return defaultInterval
+}
@@ -2,12 +2,25 @@
 
 package utils
 
-import "testing"
+import (
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/hpegl-vmaas-terraform-resources/pkg/constants"
+)
 
 type testStruct struct {
 	val int
 }
 
+type testPollClient struct {
+	interval time.Duration
+}
+
+func (c *testPollClient) GetPollInterval() time.Duration {
+	return c.interval
+}
+
 func TestIsEmpty(t *testing.T) {
 	tests := []struct {
 		name string
@@ -53,3 +66,39 @@ func TestIsEmpty(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPollInterval(t *testing.T) {
+	tests := []struct {
+		name string
+		meta interface{}
+		want time.Duration
+	}{
+		{
+			name: "Test case 1: poll interval configured",
+			meta: map[string]interface{}{constants.ClientMapKey: &testPollClient{interval: time.Second * 5}},
+			want: time.Second * 5,
+		},
+		{
+			name: "Test case 2: poll interval not configured",
+			meta: map[string]interface{}{constants.ClientMapKey: &testPollClient{}},
+			want: time.Second * 30,
+		},
+		{
+			name: "Test case 3: client not initialised",
+			meta: map[string]interface{}{},
+			want: time.Second * 30,
+		},
+		{
+			name: "Test case 4: nil meta",
+			meta: nil,
+			want: time.Second * 30,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetPollInterval(tt.meta, time.Second*30); got != tt.want {
+				t.Errorf("GetPollInterval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -16,6 +16,10 @@ const (
 	StateSuspending   = "suspending"
 	StateResizing     = "resizing"
 	StateRestarting   = "restarting"
+	// approval related states, instance waits in pendingApproval state if the
+	// group policy requires an approval
+	StatePendingApproval = "pendingApproval"
+	StateDenied          = "denied"
 	// data constants
 	ErrInvalidType   = "invalid Type"
 	ErrKeyNotDefined = "key is not defined"
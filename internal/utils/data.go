@@ -7,6 +7,7 @@ import (
 	"errors"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -143,6 +144,12 @@ func (d *Data) SetID(v interface{}) {
 	d.d.SetId(stringID)
 }
 
+// Timeout returns the timeout configured for the operation. key should be one of
+// schema.TimeoutCreate, schema.TimeoutUpdate or schema.TimeoutDelete
+func (d *Data) Timeout(key string) time.Duration {
+	return d.d.Timeout(key)
+}
+
 // nolint
 func (d *Data) SetId(v string) {
 	d.d.SetId(v)
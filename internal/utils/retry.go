@@ -97,6 +97,8 @@ func retry(
 				apiChan <- continueStruct{
 					respErr: fmt.Errorf("retry timed out"),
 				}
+
+				return
 			case continueChan := <-rChan.continueChan:
 				// check exit condition before invoking next retry
 				if i == cRetry.RetryCount-1 {
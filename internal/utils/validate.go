@@ -6,8 +6,18 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-func SkipField() schema.SchemaDiffSuppressFunc {
+// SuppressAutoDatastore suppresses the diff of a datastore ID configured as 'auto', since the state
+// holds the automatically selected datastore. Diff of any other datastore ID is not suppressed.
+func SuppressAutoDatastore() schema.SchemaDiffSuppressFunc {
 	return func(k, old, new string, d *schema.ResourceData) bool {
-		return old != ""
+		return old != "" && new == "auto"
+	}
+}
+
+// SuppressOnUpdate suppresses the diff of an existing resource, for the attributes which are
+// applied only while creating the resource.
+func SuppressOnUpdate() schema.SchemaDiffSuppressFunc {
+	return func(k, old, new string, d *schema.ResourceData) bool {
+		return d.Id() != ""
 	}
 }
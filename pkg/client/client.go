@@ -5,6 +5,7 @@ package client
 import (
 	"fmt"
 	"os"
+	"time"
 
 	api_client "github.com/HewlettPackard/hpegl-vmaas-cmp-go-sdk/pkg/client"
 	cmp_client "github.com/HewlettPackard/hpegl-vmaas-terraform-resources/internal/cmp"
@@ -16,7 +17,7 @@ import (
 
 // keyForGLClientMap is the key in the map[string]interface{} that is passed down by hpegl used to store *Client
 // This must be unique, hpegl will error-out if it isn't
-const keyForGLClientMap = "vmaasClient"
+const keyForGLClientMap = constants.ClientMapKey
 
 var serviceURL string
 
@@ -26,6 +27,14 @@ var _ client.Initialisation = (*InitialiseClient)(nil)
 // Client is the client struct that is used by the provider code
 type Client struct {
 	CmpClient *cmp_client.Client
+	// PollInterval is the delay between polls while waiting on long running operations,
+	// zero means the default of each operation is used
+	PollInterval time.Duration
+}
+
+// GetPollInterval returns the provider level poll interval
+func (c *Client) GetPollInterval() time.Duration {
+	return c.PollInterval
 }
 
 // Get env configurations for VmaaS services
@@ -64,6 +73,9 @@ func (i InitialiseClient) NewClient(r *schema.ResourceData) (interface{}, error)
 			constants.LocationKey: vmaasProviderSettings[constants.LOCATION].(string),
 		},
 	}
+	if pollInterval, ok := vmaasProviderSettings[constants.POLLINTERVAL].(int); ok {
+		client.PollInterval = time.Duration(pollInterval) * time.Second
+	}
 	apiClient := api_client.NewAPIClient(&cfg)
 	utils.SetMeta(apiClient, r)
 	client.CmpClient = cmp_client.NewClient(apiClient, cfg)
@@ -7,13 +7,16 @@ const (
 	// ServiceName - the service mnemonic
 	ServiceName = "vmaas"
 	ServiceURL  = "https://iac-vmaas.us1.greenlake-hpe.com"
+	// ClientMapKey - the key of the vmaas client in the meta passed down by hpegl
+	ClientMapKey = "vmaasClient"
 
-	LOCATION    = "location"
-	SPACENAME   = "space_name"
-	APIURL      = "api_url"
-	INSECURE    = "allow_insecure"
-	SpaceKey    = "space"
-	LocationKey = "location"
+	LOCATION     = "location"
+	SPACENAME    = "space_name"
+	APIURL       = "api_url"
+	INSECURE     = "allow_insecure"
+	POLLINTERVAL = "poll_interval"
+	SpaceKey     = "space"
+	LocationKey  = "location"
 
 	MockIAMKey     = "TF_ACC_MOCK_IAM"
 	CmpSubjectKey  = "TF_ACC_CMP_SUBJECT"
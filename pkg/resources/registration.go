@@ -41,6 +41,8 @@ func (r Registration) SupportedDataSources() map[string]*schema.Resource {
 		resources.DSEdgeCluster:      resources.EdgeClusterData(),
 		resources.DSTransportZone:    resources.TransportZoneData(),
 		resources.DSLoadBalancer:     resources.LoadBalancerData(),
+		resources.DSInstance:         resources.InstanceData(),
+		resources.DSInstances:        resources.InstancesData(),
 	}
 }
 
@@ -48,6 +50,8 @@ func (r Registration) SupportedResources() map[string]*schema.Resource {
 	return map[string]*schema.Resource{
 		resources.ResInstance:                   resources.Instances(),
 		resources.ResInstanceClone:              resources.InstancesClone(),
+		resources.ResInstanceSnapshot:           resources.InstanceSnapshot(),
+		resources.ResInstanceVolume:             resources.InstanceVolume(),
 		resources.ResNetwork:                    resources.Network(),
 		resources.ResRouter:                     resources.Router(),
 		resources.ResRouterNat:                  resources.RouterNatRule(),
@@ -83,6 +87,14 @@ func (r Registration) ProviderSchemaEntry() *schema.Resource {
 				DefaultFunc: schema.EnvDefaultFunc("HPEGL_VMAAS_API_URL", constants.ServiceURL),
 				Description: "The URL to use for the VMaaS API, can also be set with the HPEGL_VMAAS_API_URL env var",
 			},
+			constants.POLLINTERVAL: {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("HPEGL_VMAAS_POLL_INTERVAL", 0),
+				Description: `Interval in seconds between status polls while waiting for long running operations
+				such as instance creation and cloning. If not set, default interval of each operation is used.
+				Can also be set with the HPEGL_VMAAS_POLL_INTERVAL env var.`,
+			},
 		},
 	}
 }